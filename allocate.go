@@ -0,0 +1,115 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Allocate splits total into len(ratios) parts, each proportional to its
+// ratio and having scale digits after the decimal separator. The parts always
+// sum to exactly total.
+//
+// Each part is first given its proportional share of total, truncated to
+// scale digits. Any units of 10**-scale that are left over are then handed
+// out one at a time, in order of the largest discarded remainder (the largest
+// remainder method). Ties go to the part that appears first in ratios. A
+// negative total is allocated as if it were positive, and every part is then
+// negated.
+//
+// Ratios must not be negative, and at least one must be non-zero. ErrInexact
+// is returned if total has more than scale digits after the decimal
+// separator, and ErrRange if a part can not be represented with scale digits
+// after it.
+func Allocate(total *Decimal, ratios []*Decimal, scale int) ([]*Decimal, error) {
+	const fnName = "Allocate"
+
+	if err := total.checkFinite(); err != nil {
+		return nil, err
+	}
+	if len(ratios) == 0 {
+		return nil, rangeError(fnName, "[]")
+	}
+	sum := new(big.Rat)
+	for _, r := range ratios {
+		if err := r.checkFinite(); err != nil {
+			return nil, err
+		}
+		if r.Negative {
+			return nil, rangeError(fnName, r.String())
+		}
+		sum.Add(sum, r.rat())
+	}
+	if sum.Sign() == 0 {
+		return nil, rangeError(fnName, sum.RatString())
+	}
+
+	if !validScale(scale) {
+		return nil, rangeError(fnName, strconv.Itoa(scale))
+	}
+	units, exact := rescale(total.coefficient(), total.denominatorDigits, scale, Exact)
+	if !exact {
+		return nil, inexactError(fnName, total.String())
+	}
+	negative := units.Sign() < 0
+	units.Abs(units)
+
+	// Hand out the truncated shares, remembering what was discarded.
+	shares := make([]*big.Int, len(ratios))
+	remainders := make([]*big.Rat, len(ratios))
+	leftover := new(big.Int).Set(units)
+	for i, r := range ratios {
+		share := new(big.Rat).SetInt(units)
+		share.Mul(share, r.rat())
+		share.Quo(share, sum)
+		shares[i] = new(big.Int).Quo(share.Num(), share.Denom())
+		remainders[i] = share.Sub(share, new(big.Rat).SetInt(shares[i]))
+		leftover.Sub(leftover, shares[i])
+	}
+
+	// Distribute the leftover units by largest remainder. The stable sort
+	// keeps ties in their original order.
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for i := 0; leftover.Sign() > 0; i++ {
+		shares[order[i]].Add(shares[order[i]], bigOne)
+		leftover.Sub(leftover, bigOne)
+	}
+
+	parts := make([]*Decimal, len(ratios))
+	for i, share := range shares {
+		if negative {
+			share.Neg(share)
+		}
+		parts[i] = &Decimal{}
+		if !parts[i].setCoefficient(share, scale) {
+			return nil, rangeError(fnName, total.String())
+		}
+	}
+	return parts, nil
+}
+
+// Split splits total into n parts that are as equal as possible, each having
+// scale digits after the decimal separator. The parts always sum to exactly
+// total, with any units of 10**-scale that are left over going to the first
+// parts. For example, splitting 100.00 three ways returns 33.34, 33.33 and
+// 33.33. See Allocate for details.
+func Split(total *Decimal, n int, scale int) ([]*Decimal, error) {
+	if n <= 0 {
+		return nil, rangeError("Split", strconv.Itoa(n))
+	}
+	ratios := make([]*Decimal, n)
+	for i := range ratios {
+		ratios[i] = NewFromInt64(1)
+	}
+	return Allocate(total, ratios, scale)
+}
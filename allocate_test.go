@@ -0,0 +1,178 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+// joinDecimals returns the string representations of ds, separated by spaces.
+func joinDecimals(ds []*Decimal) string {
+	s := make([]string, len(ds))
+	for i, d := range ds {
+		s[i] = d.String()
+	}
+	return strings.Join(s, " ")
+}
+
+func TestAllocate(t *testing.T) {
+	type allocateTest struct {
+		description, total string
+		ratios             []string
+		scale              int
+		shouldFail         bool
+		output             string
+	}
+
+	tests := []allocateTest{
+		{
+			description: "Even split",
+			total:       "100.00",
+			ratios:      []string{"1", "1", "1", "1"},
+			scale:       2,
+			output:      "25.00 25.00 25.00 25.00",
+		},
+		{
+			description: "Remainder goes to the first part on a tie",
+			total:       "100.00",
+			ratios:      []string{"1", "1", "1"},
+			scale:       2,
+			output:      "33.34 33.33 33.33",
+		},
+		{
+			description: "Remainder goes to the largest remainder",
+			total:       "0.10",
+			ratios:      []string{"0.33", "0.67"},
+			scale:       2,
+			output:      "0.03 0.07",
+		},
+		{
+			description: "Pro-rata discount",
+			total:       "-10.00",
+			ratios:      []string{"19.99", "5.01", "75"},
+			scale:       2,
+			output:      "-2.00 -0.50 -7.50",
+		},
+		{
+			description: "Negative total",
+			total:       "-100.00",
+			ratios:      []string{"1", "1", "1"},
+			scale:       2,
+			output:      "-33.34 -33.33 -33.33",
+		},
+		{
+			description: "Zero ratio",
+			total:       "10",
+			ratios:      []string{"0", "1", "2"},
+			scale:       0,
+			output:      "0.0 3.0 7.0",
+		},
+		{
+			description: "More precision than the total",
+			total:       "1",
+			ratios:      []string{"1", "1", "1"},
+			scale:       3,
+			output:      "0.334 0.333 0.333",
+		},
+		{
+			description: "Total has too many digits",
+			total:       "1.005",
+			ratios:      []string{"1", "1"},
+			scale:       2,
+			shouldFail:  true,
+		},
+		{
+			description: "Negative ratio",
+			total:       "1.00",
+			ratios:      []string{"1", "-1"},
+			scale:       2,
+			shouldFail:  true,
+		},
+		{
+			description: "All ratios zero",
+			total:       "1.00",
+			ratios:      []string{"0", "0"},
+			scale:       2,
+			shouldFail:  true,
+		},
+		{
+			description: "No ratios",
+			total:       "1.00",
+			scale:       2,
+			shouldFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		total, err := ParseDecimal(test.total)
+		if err != nil {
+			t.Errorf("%s (input '%s'): expected success, received error '%v'.", test.description, test.total, err)
+			continue
+		}
+		ratios := make([]*Decimal, len(test.ratios))
+		for i, r := range test.ratios {
+			ratios[i], _ = ParseDecimal(r)
+		}
+		parts, err := Allocate(total, ratios, test.scale)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("%s (input '%s'): expected success, received error '%v'.", test.description, test.total, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("%s (input '%s'): expected failure.", test.description, test.total)
+			continue
+		}
+		if joinDecimals(parts) != test.output {
+			t.Errorf("%s (input '%s'): expected '%s', received '%s'.", test.description, test.total, test.output, joinDecimals(parts))
+		}
+
+		sum := total.rat()
+		for _, p := range parts {
+			sum.Sub(sum, p.rat())
+		}
+		if sum.Sign() != 0 {
+			t.Errorf("%s (input '%s'): parts do not sum to the total.", test.description, test.total)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	type splitTest struct {
+		total      string
+		n, scale   int
+		shouldFail bool
+		output     string
+	}
+
+	tests := []splitTest{
+		{total: "100.00", n: 3, scale: 2, output: "33.34 33.33 33.33"},
+		{total: "-0.05", n: 3, scale: 2, output: "-0.02 -0.02 -0.01"},
+		{total: "10", n: 4, scale: 0, output: "3.0 3.0 2.0 2.0"},
+		{total: "0", n: 2, scale: 2, output: "0.00 0.00"},
+		{total: "1.00", n: 0, scale: 2, shouldFail: true},
+		{total: "1", n: 3, scale: 20, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		total, _ := ParseDecimal(test.total)
+		parts, err := Split(total, test.n, test.scale)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s' / %d: Expected success, received error '%v'.", test.total, test.n, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s' / %d: Expected failure.", test.total, test.n)
+			continue
+		}
+		if joinDecimals(parts) != test.output {
+			t.Errorf("'%s' / %d: Expected '%s', received '%s'.", test.total, test.n, test.output, joinDecimals(parts))
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// Functions such as Pow whose results are usually irrational are evaluated
+// with big.Float, and then correctly rounded using Ziv's strategy: the value
+// is approximated with a known error bound, and if the whole interval allowed
+// by that bound does not round to the same Decimal, the approximation is
+// repeated with more precision.
+
+// guardBits is the number of bits beyond the requested precision that the
+// big.Float helpers work with, to absorb their own rounding errors.
+const guardBits = 64
+
+// log2of10 is an upper bound for log2(10), used to convert digits to bits.
+const log2of10 = 3.33
+
+// maxZivIterations is the number of times approx doubles the precision
+// before accepting a result that it can not prove is correctly rounded. That
+// only happens for results which lie exactly on a rounding boundary, and are
+// not recognised by the exact function given to setApprox.
+const maxZivIterations = 8
+
+// maxApproxExp and minApproxExp are the largest and smallest binary exponents
+// of a value that approx rounds to a Decimal without clamping it first. Beyond
+// them, a value is either too large for a Decimal, or smaller than
+// 10**-maxExponent.
+const (
+	maxApproxExp = 400
+	minApproxExp = -(maxExponent + 2) * 10 / 3
+)
+
+// approxFunc returns an approximation of a value, with a relative error of less
+// than 2**-prec.
+type approxFunc func(prec uint) *big.Float
+
+// roundRat rounds q according to the context, as ctx.set would, and also
+// signals Overflow if the result does not fit in a Decimal.
+func (ctx *Context) roundRat(q *big.Rat) (*big.Int, int, Condition) {
+	c, scale := ctx.quotient(q, 0)
+	c, scale, cond := ctx.round(c, scale)
+	if cond&Overflow == 0 && !new(Decimal).setCoefficient(c, scale) {
+		cond |= Overflow | Inexact | Rounded
+	}
+	return c, scale, cond
+}
+
+// setApprox sets d to the value approximated by f, correctly rounded according
+// to ctx. If exact is not nil, it is called with a candidate value whenever
+// the approximation is too close to a rounding boundary, and should report
+// whether the candidate is the exact result. Results that are not exact signal
+// Inexact and Rounded.
+func (ctx *Context) setApprox(fnName, num string, d *Decimal, f approxFunc, exact func(*big.Rat) bool) error {
+	c, scale, cond := ctx.approx(f, exact, true)
+	return ctx.finish(fnName, num, d, c, scale, cond)
+}
+
+// approxRat returns the value approximated by f, correctly rounded according to
+// ctx but not limited to what a Decimal can hold, as setApprox would for an
+// inexact result. The context must have a precision. A *big.Rat can be neither
+// NaN nor an infinity, so InvalidOperation and Overflow always cause an error
+// to be returned.
+func (ctx *Context) approxRat(fnName, num string, f approxFunc) (*big.Rat, error) {
+	if ctx.Precision <= 0 {
+		ctx.signal(fnName, num, InvalidOperation)
+		return nil, &NumError{fnName, num, InvalidOperation}
+	}
+	c, scale, cond := ctx.approx(f, nil, false)
+	if cond&Overflow != 0 {
+		ctx.signal(fnName, num, cond)
+		return nil, &NumError{fnName, num, cond & Overflow}
+	}
+	if err := ctx.signal(fnName, num, cond); err != nil {
+		return nil, err
+	}
+	return scaledRat(c, scale), nil
+}
+
+// approx returns the coefficient and scale of the value approximated by f,
+// correctly rounded according to ctx, and the conditions signalled. exact is
+// as for setApprox. If limited is true, the result is also rounded to fit in a
+// Decimal, and signals Overflow if it can not.
+func (ctx *Context) approx(f approxFunc, exact func(*big.Rat) bool, limited bool) (*big.Int, int, Condition) {
+	round := ctx.roundRat
+	if !limited {
+		round = func(q *big.Rat) (*big.Int, int, Condition) {
+			c, scale := ctx.quotient(q, 0)
+			return ctx.roundUnlimited(c, scale)
+		}
+	}
+
+	// Without a precision, 40 digits are enough for most values that a Decimal
+	// can hold. Those with many leading zeros after the decimal separator need
+	// more, and get them as the precision is doubled below.
+	digits := ctx.Precision
+	if digits <= 0 {
+		digits = 40
+	}
+	prec := uint(float64(digits+3)*log2of10) + 1
+
+	var c *big.Int
+	var scale int
+	var cond Condition
+	for i := 0; ; i++ {
+		r := f(prec)
+		// Beyond these bounds a value is far outside the range of a Decimal,
+		// and clamping it avoids building enormous rationals without changing
+		// how it rounds to one.
+		if exp := r.MantExp(nil); limited && exp < minApproxExp {
+			r = new(big.Float).SetMantExp(big.NewFloat(float64(r.Sign())), minApproxExp)
+		} else if limited && exp > maxApproxExp {
+			r = new(big.Float).SetMantExp(big.NewFloat(float64(r.Sign())), maxApproxExp)
+		}
+		q, _ := r.Rat(nil)
+		bound := new(big.Rat).SetFrac(new(big.Int).Abs(q.Num()), new(big.Int).Lsh(q.Denom(), prec))
+		lo := new(big.Rat).Sub(q, bound)
+		hi := new(big.Rat).Add(q, bound)
+
+		c, scale, cond = round(lo)
+		c2, scale2, cond2 := round(hi)
+		if cond&Overflow != 0 && cond2&Overflow != 0 {
+			break
+		}
+		if c.Cmp(c2) == 0 && scale == scale2 && cond&Overflow == cond2&Overflow {
+			// The result may still be exact, with fewer digits than were kept.
+			if candidate := scaledRat(c, scale); exact != nil && exact(candidate) {
+				return round(candidate)
+			}
+			break
+		}
+
+		if exact != nil {
+			candidate := roundSignificant(q, int(float64(prec)/log2of10)-2)
+			if exact(candidate) {
+				return round(candidate)
+			}
+		}
+		if i == maxZivIterations {
+			break
+		}
+		prec *= 2
+	}
+	return c, scale, cond | Inexact | Rounded
+}
+
+// scaledRat returns c * 10**-scale as a *big.Rat.
+func scaledRat(c *big.Int, scale int) *big.Rat {
+	if scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(c, pow10(-scale)))
+	}
+	return new(big.Rat).SetFrac(c, pow10(scale))
+}
+
+// roundSignificant returns q rounded to nearest even with the given number of
+// significant digits.
+func roundSignificant(q *big.Rat, digits int) *big.Rat {
+	if q.Sign() == 0 {
+		return new(big.Rat)
+	}
+	n, m := new(big.Int).Set(q.Num()), new(big.Int).Set(q.Denom())
+	scale := digits - (numDigits(n) - numDigits(m))
+	if scale >= 0 {
+		n.Mul(n, pow10(scale))
+	} else {
+		m.Mul(m, pow10(-scale))
+	}
+	c, _ := quoRound(n, m, ToNearestEven)
+	return scaledRat(c, scale)
+}
+
+// ratPow returns x**n, which must be defined.
+func ratPow(x *big.Rat, n *big.Int) *big.Rat {
+	if n.Sign() < 0 {
+		x, n = new(big.Rat).Inv(x), new(big.Int).Neg(n)
+	}
+	num := new(big.Int).Exp(x.Num(), n, nil)
+	denom := new(big.Int).Exp(x.Denom(), n, nil)
+	return new(big.Rat).SetFrac(num, denom)
+}
+
+// newFloat returns a new big.Float with the given precision, holding x.
+func newFloat(prec uint, x int64) *big.Float {
+	return new(big.Float).SetPrec(prec).SetInt64(x)
+}
+
+// bigAtanh returns atanh(z) for |z| <= 1/3, computed with the precision of z
+// using its Taylor series.
+func bigAtanh(z *big.Float) *big.Float {
+	prec := z.Prec()
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	if z.Sign() == 0 {
+		return sum
+	}
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	power := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, z2)
+		term.Quo(power, newFloat(prec, k))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec)-1 {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// bigLn2 returns ln(2) with the given precision, as 2*atanh(1/3).
+func bigLn2(prec uint) *big.Float {
+	third := new(big.Float).SetPrec(prec).Quo(newFloat(prec, 1), newFloat(prec, 3))
+	r := bigAtanh(third)
+	return r.Mul(r, newFloat(prec, 2))
+}
+
+// bigLn returns ln(x) for x > 0, with a relative error of less than 2**-prec.
+func bigLn(x *big.Float, prec uint) *big.Float {
+	wp := prec + guardBits
+
+	// x = m * 2**e, with m in [sqrt(1/2), sqrt(2)), so that ln(m) is small and
+	// there is no cancellation when adding e*ln(2).
+	m := new(big.Float).SetPrec(wp)
+	e := x.MantExp(m)
+	if m.Cmp(big.NewFloat(0.7071067811865476)) < 0 {
+		m.SetMantExp(m, 1)
+		e--
+	}
+
+	// ln(m) = 2*atanh((m-1)/(m+1))
+	z := new(big.Float).SetPrec(wp).Sub(m, newFloat(wp, 1))
+	z.Quo(z, new(big.Float).SetPrec(wp).Add(m, newFloat(wp, 1)))
+	r := bigAtanh(z)
+	r.Mul(r, newFloat(wp, 2))
+	if e != 0 {
+		ln2 := bigLn2(wp)
+		r.Add(r, ln2.Mul(ln2, newFloat(wp, int64(e))))
+	}
+	return r
+}
+
+// maxExpArg bounds the arguments of bigExp. Beyond it, the result is far
+// outside the range of a Decimal in either direction, and clamping the
+// argument keeps the result within the exponent range of a big.Float without
+// changing how it rounds.
+const maxExpArg = 1 << 30
+
+// bigExp returns e**x, with a relative error of less than 2**-prec.
+func bigExp(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return newFloat(prec, 1)
+	}
+	if new(big.Float).Abs(x).Cmp(newFloat(64, maxExpArg)) > 0 {
+		x = newFloat(64, maxExpArg*int64(x.Sign()))
+	}
+
+	// x = k*ln(2) + r, with |r| <= ln(2)/2. The error in ln(2) is multiplied
+	// by k, so it needs as many more bits as k has.
+	const halvings = 16
+	wp := prec + guardBits + halvings
+	if exp := x.MantExp(nil); exp > 0 {
+		wp += uint(exp)
+	}
+	ln2 := bigLn2(wp)
+	kf := new(big.Float).SetPrec(wp).Quo(x, ln2)
+	k, _ := kf.Add(kf, big.NewFloat(0.5*float64(kf.Sign()))).Int64()
+	r := new(big.Float).SetPrec(wp).Sub(x, ln2.Mul(ln2, newFloat(wp, k)))
+
+	// e**r = (e**(r/2**halvings))**(2**halvings), with the inner power from
+	// its Taylor series.
+	r.SetMantExp(r, -halvings)
+	sum := newFloat(wp, 1)
+	term := newFloat(wp, 1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, newFloat(wp, n))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(wp)-1 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetMantExp(sum, int(k))
+}
@@ -0,0 +1,99 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/hex"
+	"math/big"
+)
+
+func validAvroSchema(precision, scale int) bool {
+	return precision > 0 && scale >= 0 && scale <= precision && scale <= maxExponent
+}
+
+// avroCoefficient returns the unscaled value of d, adjusted to the schema
+// scale and checked against the schema precision.
+func (d *Decimal) avroCoefficient(fnName string, precision, scale int, mode RoundingMode) (*big.Int, error) {
+	if err := d.checkFinite(); err != nil {
+		return nil, err
+	}
+	if !validAvroSchema(precision, scale) {
+		return nil, &NumError{fnName, d.String(), ErrAvroSchema}
+	}
+
+	c, exact := rescale(d.coefficient(), d.denominatorDigits, scale, mode)
+	if !exact && mode == Exact {
+		return nil, inexactError(fnName, d.String())
+	}
+	if len(new(big.Int).Abs(c).String()) > precision {
+		return nil, rangeError(fnName, d.String())
+	}
+	return c, nil
+}
+
+// AvroBytes encodes d as the bytes representation of an Avro decimal logical
+// type with the given precision and scale. The result is the big-endian two's
+// complement unscaled value of d.
+//
+// If d has more fractional digits than scale allows, it is rounded according
+// to mode. If mode is Exact, ErrInexact is returned instead. ErrRange is
+// returned if the result needs more than precision digits.
+func (d *Decimal) AvroBytes(precision, scale int, mode RoundingMode) ([]byte, error) {
+	c, err := d.avroCoefficient("AvroBytes", precision, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	return twosComplement(c), nil
+}
+
+// AvroFixed encodes d as the fixed representation of an Avro decimal logical
+// type of the given size, precision and scale. It behaves like AvroBytes, but
+// the result is sign extended to exactly size bytes. ErrRange is returned if
+// the value does not fit in size bytes.
+func (d *Decimal) AvroFixed(size, precision, scale int, mode RoundingMode) ([]byte, error) {
+	const fnName = "AvroFixed"
+
+	c, err := d.avroCoefficient(fnName, precision, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	b := twosComplement(c)
+	if len(b) > size {
+		return nil, rangeError(fnName, d.String())
+	}
+
+	fixed := make([]byte, size)
+	if c.Sign() < 0 {
+		for i := range fixed {
+			fixed[i] = 0xff
+		}
+	}
+	copy(fixed[size-len(b):], b)
+	return fixed, nil
+}
+
+// DecodeAvro converts the Avro decimal logical type value b into a Decimal.
+// b may be either the bytes or the fixed representation. The decoded value
+// must have no more than precision digits.
+func DecodeAvro(b []byte, precision, scale int) (*Decimal, error) {
+	const fnName = "DecodeAvro"
+
+	if !validAvroSchema(precision, scale) {
+		return nil, &NumError{fnName, hex.EncodeToString(b), ErrAvroSchema}
+	}
+	if len(b) == 0 {
+		return nil, syntaxError(fnName, "")
+	}
+
+	c := fromTwosComplement(b)
+	if len(new(big.Int).Abs(c).String()) > precision {
+		return nil, rangeError(fnName, hex.EncodeToString(b))
+	}
+	decimal := &Decimal{}
+	if !decimal.setCoefficient(c, scale) {
+		return nil, rangeError(fnName, hex.EncodeToString(b))
+	}
+	return decimal, nil
+}
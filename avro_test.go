@@ -0,0 +1,215 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestAvroBytes(t *testing.T) {
+	type avroTest struct {
+		description, input string
+		precision, scale   int
+		mode               RoundingMode
+		shouldFail         bool
+		output             string
+	}
+
+	tests := []avroTest{
+		{
+			description: "Matching scale",
+			input:       "123.45",
+			precision:   5,
+			scale:       2,
+			output:      "3039",
+		},
+		{
+			description: "Negative value",
+			input:       "-123.45",
+			precision:   5,
+			scale:       2,
+			output:      "cfc7",
+		},
+		{
+			description: "Zero",
+			input:       "0.0",
+			precision:   5,
+			scale:       2,
+			output:      "00",
+		},
+		{
+			description: "Scale increased",
+			input:       "1.5",
+			precision:   5,
+			scale:       3,
+			output:      "05dc",
+		},
+		{
+			description: "Scale decreased, rounded",
+			input:       "1.235",
+			precision:   5,
+			scale:       2,
+			mode:        ToNearestEven,
+			output:      "7c",
+		},
+		{
+			description: "Scale decreased, rounded away",
+			input:       "-1.235",
+			precision:   5,
+			scale:       2,
+			mode:        ToNearestAway,
+			output:      "84",
+		},
+		{
+			description: "Scale decreased, exact",
+			input:       "1.230",
+			precision:   5,
+			scale:       2,
+			mode:        Exact,
+			output:      "7b",
+		},
+		{
+			description: "Scale decreased, inexact",
+			input:       "1.235",
+			precision:   5,
+			scale:       2,
+			mode:        Exact,
+			shouldFail:  true,
+		},
+		{
+			description: "Precision exceeded",
+			input:       "1234.5",
+			precision:   5,
+			scale:       2,
+			shouldFail:  true,
+		},
+		{
+			description: "Precision exceeded by rounding",
+			input:       "999.999",
+			precision:   5,
+			scale:       2,
+			shouldFail:  true,
+		},
+		{
+			description: "Invalid schema",
+			input:       "1.0",
+			precision:   2,
+			scale:       3,
+			shouldFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("%s (input '%s'): expected success, received error '%v'.", test.description, test.input, err)
+			continue
+		}
+		b, err := d.AvroBytes(test.precision, test.scale, test.mode)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("%s (input '%s'): expected success, received error '%v'.", test.description, test.input, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("%s (input '%s'): expected failure.", test.description, test.input)
+			continue
+		}
+		if hex.EncodeToString(b) != test.output {
+			t.Errorf("%s (input '%s'): expected '%s', received '%s'.", test.description, test.input, test.output, hex.EncodeToString(b))
+		}
+	}
+
+	if _, err := (&Decimal{}).AvroBytes(5, 2, ToNearestEven); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid for an invalid Decimal, received '%v'.", err)
+	}
+}
+
+func TestAvroFixed(t *testing.T) {
+	tests := map[string]string{
+		"123.45":  "00003039",
+		"-123.45": "ffffcfc7",
+		"0.00":    "00000000",
+		"-0.01":   "ffffffff",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		b, err := d.AvroFixed(4, 9, 2, Exact)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if hex.EncodeToString(b) != output {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, output, hex.EncodeToString(b))
+		}
+	}
+
+	d, _ := ParseDecimal("21474836.48")
+	if _, err := d.AvroFixed(4, 10, 2, Exact); err == nil {
+		t.Errorf("'%s': Expected failure, value does not fit in 4 bytes.", d.String())
+	}
+}
+
+func TestDecodeAvro(t *testing.T) {
+	type decodeTest struct {
+		input            string
+		precision, scale int
+		shouldFail       bool
+		output           string
+	}
+
+	tests := []decodeTest{
+		{input: "3039", precision: 5, scale: 2, output: "123.45"},
+		{input: "cfc7", precision: 5, scale: 2, output: "-123.45"},
+		{input: "ffffcfc7", precision: 5, scale: 2, output: "-123.45"},
+		{input: "00", precision: 5, scale: 2, output: "0.00"},
+		{input: "05dc", precision: 5, scale: 3, output: "1.500"},
+		{input: "3039", precision: 5, scale: 0, output: "12345.0"},
+		{input: "3039", precision: 4, scale: 2, shouldFail: true},
+		{input: "", precision: 4, scale: 2, shouldFail: true},
+		{input: "3039", precision: 4, scale: 5, shouldFail: true},
+		{input: "010000000000000000", precision: 30, scale: 0, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		b, _ := hex.DecodeString(test.input)
+		d, err := DecodeAvro(b, test.precision, test.scale)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s' (%d, %d): expected success, received error '%v'.", test.input, test.precision, test.scale, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s' (%d, %d): expected failure.", test.input, test.precision, test.scale)
+			continue
+		}
+		if d.String() != test.output {
+			t.Errorf("'%s' (%d, %d): expected '%s', received '%s'.", test.input, test.precision, test.scale, test.output, d.String())
+		}
+	}
+}
+
+func TestAvroSchemaError(t *testing.T) {
+	d, _ := ParseDecimal("1.5")
+	for _, schema := range [][2]int{{0, 0}, {5, -1}, {2, 3}} {
+		if _, err := d.AvroBytes(schema[0], schema[1], ToNearestEven); err == nil || err.(*NumError).Err != ErrAvroSchema {
+			t.Errorf("AvroBytes (%d, %d): Expected ErrAvroSchema, received '%v'.", schema[0], schema[1], err)
+		}
+		if _, err := d.AvroFixed(8, schema[0], schema[1], ToNearestEven); err == nil || err.(*NumError).Err != ErrAvroSchema {
+			t.Errorf("AvroFixed (%d, %d): Expected ErrAvroSchema, received '%v'.", schema[0], schema[1], err)
+		}
+		if _, err := DecodeAvro([]byte{0x0f}, schema[0], schema[1]); err == nil || err.(*NumError).Err != ErrAvroSchema {
+			t.Errorf("DecodeAvro (%d, %d): Expected ErrAvroSchema, received '%v'.", schema[0], schema[1], err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// Rat returns the exact value of d as a *big.Rat. nil is returned if d is not
+// Valid, or is NaN or an infinity.
+func (d *Decimal) Rat() *big.Rat {
+	if !d.IsFinite() {
+		return nil
+	}
+	return d.rat()
+}
+
+// SetRat sets d to the value of r, rounded to scale digits after the decimal
+// separator according to mode. The returned Accuracy reports whether d is
+// exactly, Below or Above the value of r.
+//
+// If rounding is required and mode is Exact, ErrInexact is returned. ErrRange
+// is returned if the result can not be represented by a Decimal, or if scale
+// is less than -10000 or more than 10000. d is unchanged on error.
+func (d *Decimal) SetRat(r *big.Rat, scale int, mode RoundingMode) (big.Accuracy, error) {
+	return d.setRat("SetRat", r, scale, mode)
+}
+
+// setRat implements SetRat, reporting fnName as the failing function.
+func (d *Decimal) setRat(fnName string, r *big.Rat, scale int, mode RoundingMode) (big.Accuracy, error) {
+	if !validScale(scale) {
+		return big.Exact, rangeError(fnName, r.RatString())
+	}
+	n, m := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	if scale >= 0 {
+		n.Mul(n, pow10(scale))
+	} else {
+		m.Mul(m, pow10(-scale))
+	}
+	c, exact := quoRound(n, m, mode)
+	if !exact && mode == Exact {
+		return big.Exact, inexactError(fnName, r.RatString())
+	}
+	if !d.setCoefficient(c, scale) {
+		return big.Exact, rangeError(fnName, r.RatString())
+	}
+	if exact {
+		return big.Exact, nil
+	}
+	return accuracy(new(big.Int).Mul(c, m).Cmp(n)), nil
+}
+
+// accuracy converts the result of comparing a rounded value to the exact value
+// into a big.Accuracy.
+func accuracy(cmp int) big.Accuracy {
+	switch {
+	case cmp < 0:
+		return big.Below
+	case cmp > 0:
+		return big.Above
+	}
+	return big.Exact
+}
+
+// BigInt returns the integer part of d as a *big.Int. The fractional part is
+// discarded (truncated towards zero), and the returned Accuracy reports
+// whether the result is exactly, Below or Above the value of d. nil is
+// returned if d is not Valid, or is NaN or an infinity.
+func (d *Decimal) BigInt() (*big.Int, big.Accuracy) {
+	if !d.IsFinite() {
+		return nil, big.Exact
+	}
+	i := new(big.Int).SetUint64(d.numerator)
+	if d.Negative {
+		i.Neg(i)
+	}
+	if d.denominator == 0 {
+		return i, big.Exact
+	}
+	if d.Negative {
+		return i, big.Above
+	}
+	return i, big.Below
+}
+
+// SetBigInt sets d to the value i * 10**-scale. For example, an i of 12345 and
+// a scale of 2 sets d to 123.45. ErrRange is returned if the result can not be
+// represented by a Decimal, or if scale is out of bounds as for SetRat. d is
+// unchanged on error.
+func (d *Decimal) SetBigInt(i *big.Int, scale int) error {
+	if !d.setCoefficient(i, scale) {
+		return rangeError("SetBigInt", i.String()+"e"+big.NewInt(int64(-scale)).String())
+	}
+	return nil
+}
+
+// BigFloat returns the value of d as a *big.Float with the given precision, in
+// bits, rounded to nearest even. The returned Accuracy reports whether the
+// result is exactly, Below or Above the value of d. If prec is 0, the precision
+// is chosen as by big.Float.SetRat: the larger of 64 and the bit lengths of the
+// numerator and denominator of d. That is not always exact, as with 0.1, which
+// no binary value holds. An infinity is converted to the equivalent
+// *big.Float. nil is returned if d is not Valid, or is NaN, which a *big.Float
+// can not hold.
+func (d *Decimal) BigFloat(prec uint) (*big.Float, big.Accuracy) {
+	if !d.Valid || d.IsNaN() {
+		return nil, big.Exact
+	}
+	if d.form == infinite {
+		return new(big.Float).SetPrec(prec).SetInf(d.Negative), big.Exact
+	}
+	f := new(big.Float).SetPrec(prec).SetRat(d.rat())
+	return f, f.Acc()
+}
+
+// SetBigFloat sets d to the value of f, rounded to scale digits after the
+// decimal separator according to mode. It otherwise behaves like SetRat. An
+// infinity sets d to the equivalent infinity.
+func (d *Decimal) SetBigFloat(f *big.Float, scale int, mode RoundingMode) (big.Accuracy, error) {
+	if f.IsInf() {
+		*d = *Inf(f.Sign())
+		return big.Exact, nil
+	}
+	r, _ := f.Rat(nil)
+	return d.setRat("SetBigFloat", r, scale, mode)
+}
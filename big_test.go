@@ -0,0 +1,169 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRat(t *testing.T) {
+	tests := map[string]string{
+		"0.0":     "0",
+		"123.45":  "2469/20",
+		"-123.45": "-2469/20",
+		"1.50":    "3/2",
+		"18446744073709551615.18446744073709551615": "368934881474191032303689348814741910323/20000000000000000000",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.Rat().RatString() != output {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, output, d.Rat().RatString())
+		}
+	}
+
+	if (&Decimal{}).Rat() != nil {
+		t.Errorf("Expected nil for an invalid Decimal.")
+	}
+}
+
+func TestSetRat(t *testing.T) {
+	type setRatTest struct {
+		input      string
+		scale      int
+		mode       RoundingMode
+		shouldFail bool
+		output     string
+		acc        big.Accuracy
+	}
+
+	tests := []setRatTest{
+		{input: "2469/20", scale: 2, output: "123.45", acc: big.Exact},
+		{input: "2469/20", scale: 4, output: "123.4500", acc: big.Exact},
+		{input: "1/3", scale: 4, mode: ToNearestEven, output: "0.3333", acc: big.Below},
+		{input: "2/3", scale: 4, mode: ToNearestEven, output: "0.6667", acc: big.Above},
+		{input: "-2/3", scale: 4, mode: ToNearestEven, output: "-0.6667", acc: big.Below},
+		{input: "-2/3", scale: 4, mode: ToZero, output: "-0.6666", acc: big.Above},
+		{input: "1/8", scale: 2, mode: ToNearestEven, output: "0.12", acc: big.Below},
+		{input: "1/8", scale: 2, mode: ToNearestAway, output: "0.13", acc: big.Above},
+		{input: "-1/3", scale: 2, mode: ToNearestEven, output: "-0.33", acc: big.Above},
+		{input: "-1/300", scale: 2, mode: ToNearestEven, output: "0.00", acc: big.Above},
+		{input: "12345", scale: -2, mode: ToNearestEven, output: "12300.0", acc: big.Below},
+		{input: "1/3", scale: 4, mode: Exact, shouldFail: true},
+		{input: "18446744073709551616", scale: 0, shouldFail: true},
+		{input: "1/2", scale: 1 << 20, mode: ToNearestEven, shouldFail: true},
+		{input: "1/2", scale: -1 << 40, mode: ToNearestEven, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		r, _ := new(big.Rat).SetString(test.input)
+		d := &Decimal{}
+		acc, err := d.SetRat(r, test.scale, test.mode)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s' (scale %d, %v): expected success, received error '%v'.", test.input, test.scale, test.mode, err)
+			} else if d.Valid {
+				t.Errorf("'%s' (scale %d, %v): expected the Decimal to be unchanged.", test.input, test.scale, test.mode)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s' (scale %d, %v): expected failure.", test.input, test.scale, test.mode)
+			continue
+		}
+		if d.String() != test.output || acc != test.acc {
+			t.Errorf("'%s' (scale %d, %v): expected '%s' (%v), received '%s' (%v).", test.input, test.scale, test.mode, test.output, test.acc, d.String(), acc)
+		}
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	type bigIntTest struct {
+		input  string
+		output string
+		acc    big.Accuracy
+	}
+
+	tests := []bigIntTest{
+		{input: "123", output: "123", acc: big.Exact},
+		{input: "123.00", output: "123", acc: big.Exact},
+		{input: "123.45", output: "123", acc: big.Below},
+		{input: "-123.45", output: "-123", acc: big.Above},
+		{input: "-0.45", output: "0", acc: big.Above},
+		{input: "18446744073709551615.5", output: "18446744073709551615", acc: big.Below},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		i, acc := d.BigInt()
+		if i.String() != test.output || acc != test.acc {
+			t.Errorf("'%s': Expected %s (%v), received %s (%v).", test.input, test.output, test.acc, i.String(), acc)
+		}
+	}
+}
+
+func TestSetBigInt(t *testing.T) {
+	d := &Decimal{}
+	if err := d.SetBigInt(big.NewInt(-12345), 2); err != nil || d.String() != "-123.45" {
+		t.Errorf("Expected '-123.45', received '%s' (error '%v').", d.String(), err)
+	}
+	i, _ := new(big.Int).SetString("1844674407370955161518446744073709551615", 10)
+	if err := d.SetBigInt(i, 20); err != nil || d.String() != "18446744073709551615.18446744073709551615" {
+		t.Errorf("Expected '18446744073709551615.18446744073709551615', received '%s' (error '%v').", d.String(), err)
+	}
+	if err := d.SetBigInt(i, 19); err == nil {
+		t.Errorf("Expected failure, received '%s'.", d.String())
+	}
+	if err := d.SetBigInt(big.NewInt(0), -1<<40); err == nil {
+		t.Errorf("Expected failure, received '%s'.", d.String())
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	d, _ := ParseDecimal("0.1")
+	f, acc := d.BigFloat(53)
+	if v, _ := f.Float64(); v != 0.1 || acc != big.Above {
+		t.Errorf("Expected %g (Above), received %g (%v).", 0.1, v, acc)
+	}
+	f, acc = d.BigFloat(24)
+	if v, _ := f.Float32(); v != float32(0.1) || acc != big.Above {
+		t.Errorf("Expected %g (Above), received %g (%v).", float32(0.1), v, acc)
+	}
+	f, acc = d.BigFloat(0)
+	if f.Prec() != 64 || acc == big.Exact {
+		t.Errorf("Expected an inexact result with 64 bits of precision, received %d bits (%v).", f.Prec(), acc)
+	}
+
+	d, _ = ParseDecimal("-2.5")
+	f, acc = d.BigFloat(0)
+	if v, _ := f.Float64(); v != -2.5 || acc != big.Exact {
+		t.Errorf("Expected -2.5 (Exact), received %g (%v).", v, acc)
+	}
+}
+
+func TestSetBigFloat(t *testing.T) {
+	d := &Decimal{}
+	acc, err := d.SetBigFloat(big.NewFloat(0.1), 20, ToNearestEven)
+	if err != nil || d.String() != "0.10000000000000000555" || acc != big.Below {
+		t.Errorf("Expected '0.10000000000000000555' (Below), received '%s' (%v, error '%v').", d.String(), acc, err)
+	}
+	acc, err = d.SetBigFloat(big.NewFloat(-1.25), 1, ToNearestEven)
+	if err != nil || d.String() != "-1.2" || acc != big.Above {
+		t.Errorf("Expected '-1.2' (Above), received '%s' (%v, error '%v').", d.String(), acc, err)
+	}
+	if _, err = d.SetBigFloat(big.NewFloat(math.Inf(-1)), 2, ToNearestEven); err != nil || !d.IsInf(-1) {
+		t.Errorf("Expected '-Infinity', received '%s' (error '%v').", d.String(), err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+)
+
+// CBOR major types and tags, as defined by RFC 8949.
+const (
+	cborUnsigned    = 0
+	cborNegative    = 1
+	cborByteString  = 2
+	cborArray       = 4
+	cborTag         = 6
+	cborTagBignum   = 2
+	cborTagNegative = 3
+	cborTagDecimal  = 4
+	cborNull        = 0xf6
+)
+
+// appendCBORHead appends a CBOR data item head with the given major type and
+// argument to b.
+func appendCBORHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= 0xff:
+		return append(b, major|24, byte(arg))
+	case arg <= 0xffff:
+		return append(b, major|25, byte(arg>>8), byte(arg))
+	case arg <= 0xffffffff:
+		return append(b, major|26, byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	}
+	b = append(b, major|27)
+	return binary.BigEndian.AppendUint64(b, arg)
+}
+
+// appendCBORInt appends c to b as a CBOR integer, using a bignum if needed.
+func appendCBORInt(b []byte, c *big.Int) []byte {
+	major, tag := byte(cborUnsigned), uint64(cborTagBignum)
+	n := c
+	if c.Sign() < 0 {
+		major, tag = cborNegative, cborTagNegative
+		n = new(big.Int).Neg(c)
+		n.Sub(n, bigOne)
+	}
+	if n.IsUint64() {
+		return appendCBORHead(b, major, n.Uint64())
+	}
+	bytes := n.Bytes()
+	b = appendCBORHead(b, cborTag, tag)
+	b = appendCBORHead(b, cborByteString, uint64(len(bytes)))
+	return append(b, bytes...)
+}
+
+// MarshalCBOR implements the Marshaler interface used by CBOR libraries such
+// as github.com/fxamacker/cbor. The Decimal is encoded as a decimal fraction
+// (tag 4), an array holding the exponent and the mantissa. Mantissas that do
+// not fit in a CBOR integer are encoded as bignums (tags 2 and 3). A Decimal
+// that is not Valid is encoded as null. ErrNotFinite is returned for NaN and
+// infinities.
+func (d *Decimal) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return []byte{cborNull}, nil
+	}
+	if d.form != finite {
+		return nil, &NumError{"MarshalCBOR", d.String(), ErrNotFinite}
+	}
+
+	b := appendCBORHead(nil, cborTag, cborTagDecimal)
+	b = appendCBORHead(b, cborArray, 2)
+	b = appendCBORInt(b, big.NewInt(-int64(d.denominatorDigits)))
+	return appendCBORInt(b, d.coefficient()), nil
+}
+
+// cborReader decodes the subset of CBOR needed for decimal fractions.
+type cborReader struct {
+	data []byte
+	pos  int
+}
+
+// head reads a data item head, returning its major type and argument.
+// Indefinite lengths are not supported.
+func (r *cborReader) head() (byte, uint64, bool) {
+	if r.pos >= len(r.data) {
+		return 0, 0, false
+	}
+	major, info := r.data[r.pos]>>5, r.data[r.pos]&0x1f
+	r.pos++
+	if info < 24 {
+		return major, uint64(info), true
+	}
+	if info > 27 {
+		return 0, 0, false
+	}
+	n := 1 << (info - 24)
+	if len(r.data)-r.pos < n {
+		return 0, 0, false
+	}
+	var arg uint64
+	for _, c := range r.data[r.pos : r.pos+n] {
+		arg = arg<<8 | uint64(c)
+	}
+	r.pos += n
+	return major, arg, true
+}
+
+// integer reads a CBOR integer, which may be a bignum.
+func (r *cborReader) integer() (*big.Int, bool) {
+	major, arg, ok := r.head()
+	if !ok {
+		return nil, false
+	}
+	switch major {
+	case cborUnsigned:
+		return new(big.Int).SetUint64(arg), true
+	case cborNegative:
+		n := new(big.Int).SetUint64(arg)
+		n.Add(n, bigOne)
+		return n.Neg(n), true
+	case cborTag:
+		if arg != cborTagBignum && arg != cborTagNegative {
+			return nil, false
+		}
+		tag := arg
+		major, arg, ok = r.head()
+		if !ok || major != cborByteString || uint64(len(r.data)-r.pos) < arg {
+			return nil, false
+		}
+		n := new(big.Int).SetBytes(r.data[r.pos : r.pos+int(arg)])
+		r.pos += int(arg)
+		if tag == cborTagNegative {
+			n.Add(n, bigOne)
+			n.Neg(n)
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface used by CBOR libraries
+// such as github.com/fxamacker/cbor. data must hold a single decimal fraction
+// (tag 4) or null. Null sets Valid to false.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	const fnName = "UnmarshalCBOR"
+
+	if len(data) == 1 && data[0] == cborNull {
+		*d = Decimal{}
+		return nil
+	}
+
+	r := &cborReader{data: data}
+	if major, arg, ok := r.head(); !ok || major != cborTag || arg != cborTagDecimal {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+	if major, arg, ok := r.head(); !ok || major != cborArray || arg != 2 {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+	exponent, ok := r.integer()
+	if !ok {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+	mantissa, ok := r.integer()
+	if !ok || r.pos != len(data) {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+
+	if !exponent.IsInt64() || exponent.Int64() < -maxExponent || exponent.Int64() > maxExponent {
+		return rangeError(fnName, hex.EncodeToString(data))
+	}
+	decimal := Decimal{}
+	if !decimal.setCoefficient(mantissa, -int(exponent.Int64())) {
+		return rangeError(fnName, hex.EncodeToString(data))
+	}
+	*d = decimal
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestMarshalCBOR(t *testing.T) {
+	tests := map[string]string{
+		// From RFC 8949, section 3.4.4.
+		"273.15":                 "c48221196ab3",
+		"-1.5":                   "c482202e",
+		"0.0":                    "c4822000",
+		"100":                    "c4820018 64",
+		"-100":                   "c482003863",
+		"18446744073709551615.0": "c48220c24909fffffffffffffff6",
+		"-18446744073709551615":  "c482003bfffffffffffffffe",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		b, err := d.MarshalCBOR()
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if hex.EncodeToString(b) != stripSpaces(output) {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, stripSpaces(output), hex.EncodeToString(b))
+		}
+
+		decoded := &Decimal{}
+		if err := decoded.UnmarshalCBOR(b); err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if decoded.String() != d.String() {
+			t.Errorf("'%s': Expected '%s' after round trip, received '%s'.", input, d.String(), decoded.String())
+		}
+	}
+
+	b, err := (&Decimal{}).MarshalCBOR()
+	if err != nil || hex.EncodeToString(b) != "f6" {
+		t.Errorf("Expected an invalid Decimal to encode as null, received '%x' (error '%v').", b, err)
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	tests := map[string]string{
+		// Positive exponent.
+		"c4820205": "500.0",
+		// Negative bignum mantissa.
+		"c48221c3420100": "-2.57",
+		// Exponent encoded with a longer head than needed.
+		"c4823802190100": "0.256",
+	}
+
+	for input, output := range tests {
+		b, _ := hex.DecodeString(input)
+		d := &Decimal{}
+		if err := d.UnmarshalCBOR(b); err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.String() != output {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+
+	d, _ := ParseDecimal("1.5")
+	if err := d.UnmarshalCBOR([]byte{0xf6}); err != nil || d.Valid {
+		t.Errorf("Expected null to decode as an invalid Decimal, received '%s' (error '%v').", d.String(), err)
+	}
+
+	failures := []string{
+		"",
+		"c5822000",
+		"c4832000",
+		"c48220",
+		"c4822000 00",
+		"c482f97e00",
+		"c482201f",
+		"c48220c24900",
+		"c4821a7fffffff01",
+		"c48200c249010000000000000000",
+	}
+	for _, input := range failures {
+		b, _ := hex.DecodeString(stripSpaces(input))
+		if err := (&Decimal{}).UnmarshalCBOR(b); err == nil {
+			t.Errorf("'%s': Expected failure.", input)
+		}
+	}
+}
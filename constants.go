@@ -0,0 +1,83 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"sync"
+)
+
+// constant caches the most precise approximation computed so far of a
+// mathematical constant. Requests for less precision are rounded from it.
+type constant struct {
+	mu      sync.Mutex
+	value   *big.Float
+	compute func(prec uint) *big.Float
+}
+
+var (
+	piConstant = &constant{compute: computePi}
+	eConstant  = &constant{compute: func(prec uint) *big.Float { return bigExp(newFloat(prec, 1), prec) }}
+)
+
+// get returns an approximation of the constant with a relative error of less
+// than 2**-prec. The result may be modified by the caller.
+func (c *constant) get(prec uint) *big.Float {
+	wp := prec + guardBits
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.value == nil || c.value.Prec() < wp {
+		c.value = c.compute(wp)
+	}
+	return new(big.Float).SetPrec(wp).Set(c.value)
+}
+
+// computePi returns pi with a relative error of less than 2**-prec, using
+// Machin's formula: pi = 16*atan(1/5) - 4*atan(1/239).
+func computePi(prec uint) *big.Float {
+	wp := prec + guardBits
+	a := bigAtanSeries(new(big.Float).SetPrec(wp).Quo(newFloat(wp, 1), newFloat(wp, 5)))
+	b := bigAtanSeries(new(big.Float).SetPrec(wp).Quo(newFloat(wp, 1), newFloat(wp, 239)))
+	a.Mul(a, newFloat(wp, 16))
+	return a.Sub(a, b.Mul(b, newFloat(wp, 4)))
+}
+
+// Pi returns pi, correctly rounded according to ctx. The result is always
+// inexact, and like any Decimal has at most 19 digits after the decimal
+// separator, however large the precision of ctx; PiRat gives more.
+// Approximations of pi are cached, so that repeated calls for the same
+// precision are cheap.
+func Pi(ctx *Context) (*Decimal, error) {
+	d := new(Decimal)
+	if err := ctx.setApprox("Pi", "pi", d, piConstant.get, nil); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// E returns e, the base of natural logarithms, correctly rounded according to
+// ctx. As with Pi, the result is always inexact, and approximations are
+// cached.
+func E(ctx *Context) (*Decimal, error) {
+	d := new(Decimal)
+	if err := ctx.setApprox("E", "e", d, eConstant.get, nil); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// PiRat returns pi as a *big.Rat, correctly rounded to the precision of ctx.
+// Unlike Pi, the result is not limited to what a Decimal can hold, so it has
+// as many digits as ctx asks for. ctx must have a precision: with unlimited
+// precision, InvalidOperation is signalled and an error is returned.
+func PiRat(ctx *Context) (*big.Rat, error) {
+	return ctx.approxRat("PiRat", "pi", piConstant.get)
+}
+
+// ERat returns e as a *big.Rat, correctly rounded to the precision of ctx, as
+// PiRat does for pi.
+func ERat(ctx *Context) (*big.Rat, error) {
+	return ctx.approxRat("ERat", "e", eConstant.get)
+}
@@ -0,0 +1,99 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestConstants(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     func(*Context) (*Decimal, error)
+		ctx    Context
+		output string
+		flags  Condition
+	}{
+		{name: "Pi", fn: Pi, ctx: Context{Precision: 16}, output: "3.141592653589793", flags: Inexact | Rounded},
+		{name: "Pi", fn: Pi, ctx: Context{Precision: 5, Rounding: ToPositiveInf}, output: "3.1416", flags: Inexact | Rounded},
+		{name: "Pi", fn: Pi, ctx: Context{Precision: 5, Rounding: ToZero}, output: "3.1415", flags: Inexact | Rounded},
+		{name: "Pi", fn: Pi, ctx: Context{Precision: 30}, output: "3.1415926535897932385", flags: Clamped | Inexact | Rounded},
+		{name: "Pi", fn: Pi, ctx: Context{}, output: "3.1415926535897932385", flags: Clamped | Inexact | Rounded},
+		{name: "E", fn: E, ctx: Context{Precision: 16}, output: "2.718281828459045", flags: Inexact | Rounded},
+		{name: "E", fn: E, ctx: Context{Precision: 3, Rounding: ToNegativeInf}, output: "2.71", flags: Inexact | Rounded},
+		{name: "E", fn: E, ctx: Context{}, output: "2.7182818284590452354", flags: Clamped | Inexact | Rounded},
+	}
+
+	for _, test := range tests {
+		ctx := test.ctx
+		d, err := test.fn(&ctx)
+		if err != nil || d.String() != test.output {
+			t.Errorf("%s to %d digits: Expected '%s', received '%v' (error '%v').", test.name, test.ctx.Precision, test.output, d, err)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("%s to %d digits: Expected flags '%v', received '%v'.", test.name, test.ctx.Precision, test.flags, ctx.Flags)
+		}
+	}
+
+	ctx := Context{Precision: 5, Traps: Inexact}
+	if d, err := Pi(&ctx); d != nil || err == nil || err.(*NumError).Err != Inexact {
+		t.Errorf("Expected a trapped Inexact error, received '%v' (error '%v').", d, err)
+	}
+}
+
+func TestConstantsRat(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     func(*Context) (*big.Rat, error)
+		ctx    Context
+		output string
+	}{
+		{name: "PiRat", fn: PiRat, ctx: Context{Precision: 35}, output: "3.1415926535897932384626433832795029"},
+		{name: "PiRat", fn: PiRat, ctx: Context{Precision: 60, Rounding: ToZero}, output: "3.14159265358979323846264338327950288419716939937510582097494"},
+		{name: "PiRat", fn: PiRat, ctx: Context{Precision: 5, Rounding: ToPositiveInf}, output: "3.1416"},
+		{name: "ERat", fn: ERat, ctx: Context{Precision: 30}, output: "2.71828182845904523536028747135"},
+	}
+
+	for _, test := range tests {
+		ctx := test.ctx
+		r, err := test.fn(&ctx)
+		expected, _ := new(big.Rat).SetString(test.output)
+		if err != nil || r.Cmp(expected) != 0 {
+			t.Errorf("%s to %d digits: Expected '%s', received '%v' (error '%v').", test.name, test.ctx.Precision, test.output, r, err)
+		}
+		if ctx.Flags != Inexact|Rounded {
+			t.Errorf("%s to %d digits: Expected flags '%v', received '%v'.", test.name, test.ctx.Precision, Inexact|Rounded, ctx.Flags)
+		}
+	}
+
+	ctx := Context{}
+	if r, err := PiRat(&ctx); r != nil || err == nil || err.(*NumError).Err != InvalidOperation || ctx.Flags != InvalidOperation {
+		t.Errorf("Expected an InvalidOperation error without a precision, received '%v' (error '%v').", r, err)
+	}
+	ctx = Context{Precision: 30, Rounding: Exact}
+	if r, err := ERat(&ctx); r != nil || err == nil || err.(*NumError).Err != Inexact {
+		t.Errorf("Expected an Inexact error, received '%v' (error '%v').", r, err)
+	}
+}
+
+func TestConstantsAreCached(t *testing.T) {
+	ctx := Context{Precision: 60}
+	if _, err := Pi(&ctx); err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	cached := piConstant.value
+
+	// Less precision is rounded from the cached value.
+	for _, precision := range []int{1, 16, 60} {
+		ctx := Context{Precision: precision}
+		if _, err := Pi(&ctx); err != nil {
+			t.Fatalf("Expected success, received error '%v'.", err)
+		}
+		if piConstant.value != cached {
+			t.Errorf("%d digits: Expected the cached value to be reused.", precision)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Condition is a set of the exceptional conditions of the General Decimal
+// Arithmetic specification, which may be signalled by an operation performed
+// within a Context. A Condition is also an error, which is returned as the Err
+// of a *NumError when a condition is trapped.
+type Condition uint16
+
+// The conditions that may be signalled by an operation.
+const (
+	Clamped          Condition = 1 << iota // the exponent of the result was altered to fit
+	DivisionByZero                         // a non-zero dividend was divided by zero
+	Inexact                                // non-zero digits were discarded from the result
+	InvalidOperation                       // the operation has no meaningful result
+	Overflow                               // the result is too large to be represented
+	Rounded                                // digits, possibly zero, were discarded from the result
+	Subnormal                              // the adjusted exponent of the result is less than Emin
+	Underflow                              // the result is subnormal and inexact
+)
+
+var conditionNames = [...]string{
+	"Clamped",
+	"DivisionByZero",
+	"Inexact",
+	"InvalidOperation",
+	"Overflow",
+	"Rounded",
+	"Subnormal",
+	"Underflow",
+}
+
+// String returns the names of the conditions in c, separated by ", ".
+func (c Condition) String() string {
+	var names []string
+	for i, name := range conditionNames {
+		if c&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "no conditions"
+	}
+	return strings.Join(names, ", ")
+}
+
+func (c Condition) Error() string {
+	return c.String()
+}
+
+// maxScale is the largest number of digits after the decimal separator, not
+// counting leading zeros, that a Decimal can always hold. Results with more are
+// clamped to it.
+const maxScale = 19
+
+// Context holds the parameters of a sequence of arithmetic operations, as
+// described by the General Decimal Arithmetic specification, and accumulates
+// the conditions that they signal.
+//
+// The zero Context has unlimited precision and exponent range, and traps
+// nothing. Results are then limited only by what a Decimal can hold: digits
+// after the decimal separator beyond the first 19 that follow any leading
+// zeros are rounded away, signalling Clamped, and integer parts that do not fit
+// in a uint64 overflow.
+//
+// When they are not trapped, DivisionByZero gives an infinity of the
+// appropriate sign, and InvalidOperation gives NaN. Overflow also gives an
+// infinity, unless Rounding is ToZero or is directed towards zero for the sign
+// of the result, which instead gives the largest finite value allowed by the
+// context and by a Decimal. Inexact always causes an error to be returned when
+// Rounding is Exact.
+type Context struct {
+	Precision      int          // significant digits in a result; 0 means unlimited
+	Rounding       RoundingMode // how results are rounded
+	Emin           int          // smallest adjusted exponent of a normal result
+	Emax           int          // largest adjusted exponent of a result
+	ExponentLimits bool         // whether Emin and Emax apply; if not, the exponent range is unlimited
+	Traps          Condition    // conditions which cause an error to be returned
+	Flags          Condition    // conditions signalled so far; never cleared by operations
+}
+
+// DefaultContext is a Context with 19 digits of precision, rounding half to
+// even, that traps DivisionByZero, InvalidOperation and Overflow. Copy it
+// before use, so that flags are not shared:
+//
+//	ctx := decimal.DefaultContext
+//	err := d1.AddContext(d2, &ctx)
+//
+// The 28 digits that are customary for decimal arithmetic are not used, as a
+// Decimal can not hold them in general: at most 19 digits follow the decimal
+// separator, so a result such as 1/3 is limited to 19 significant digits. A
+// Context with a Precision of 28 can still be used, but results that need more
+// than 19 digits after the decimal separator are clamped, signalling Clamped.
+var DefaultContext = Context{
+	Precision:      19,
+	Rounding:       ToNearestEven,
+	Emin:           -999999,
+	Emax:           999999,
+	ExponentLimits: true,
+	Traps:          DivisionByZero | InvalidOperation | Overflow,
+}
+
+// numDigits returns the number of decimal digits in c, ignoring its sign. Zero
+// has one digit.
+func numDigits(c *big.Int) int {
+	if c.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(c).String())
+}
+
+// fractionDigits returns the number of digits after the decimal separator in
+// c * 10**-scale, not counting leading zeros.
+func fractionDigits(c *big.Int, scale int) int {
+	if scale <= 0 {
+		return 0
+	}
+	f := new(big.Int).Rem(c, pow10(scale))
+	if f.Sign() == 0 {
+		return 0
+	}
+	return numDigits(f)
+}
+
+// discard removes n digits from c, which has the given scale, rounding
+// according to the context. The conditions signalled are returned.
+func (ctx *Context) discard(c *big.Int, scale, n int) (*big.Int, int, Condition) {
+	c, exact := quoRound(c, pow10(n), ctx.Rounding)
+	if exact {
+		return c, scale - n, Rounded
+	}
+	return c, scale - n, Rounded | Inexact
+}
+
+// round applies the precision and exponent limits of the context, as well as
+// the limits of a Decimal, to the coefficient c with the given scale. The
+// conditions signalled are returned.
+func (ctx *Context) round(c *big.Int, scale int) (*big.Int, int, Condition) {
+	c, scale, cond := ctx.roundUnlimited(c, scale)
+	if cond&Overflow != 0 {
+		return c, scale, cond
+	}
+
+	var signalled Condition
+	n := fractionDigits(c, scale) - maxScale
+	if m := scale - maxExponent; m > n {
+		n = m
+	}
+	if n > 0 {
+		c, scale, signalled = ctx.discard(c, scale, n)
+		cond |= signalled | Clamped
+		if fractionDigits(c, scale) > maxScale {
+			c.Quo(c, bigTen)
+			scale--
+		}
+	}
+	return c, scale, cond
+}
+
+// roundUnlimited applies the precision and exponent limits of the context to
+// the coefficient c with the given scale, but not the limits of a Decimal. The
+// conditions signalled are returned.
+func (ctx *Context) roundUnlimited(c *big.Int, scale int) (*big.Int, int, Condition) {
+	var cond, signalled Condition
+	if ctx.Precision > 0 {
+		if n := numDigits(c) - ctx.Precision; n > 0 {
+			c, scale, signalled = ctx.discard(c, scale, n)
+			cond |= signalled
+
+			// Rounding up may have carried into a new digit, which is zero.
+			if numDigits(c) > ctx.Precision {
+				c.Quo(c, bigTen)
+				scale--
+			}
+		}
+	}
+
+	if c.Sign() != 0 {
+		adjusted := numDigits(c) - 1 - scale
+		if ctx.ExponentLimits && adjusted > ctx.Emax {
+			return c, scale, cond | Overflow | Inexact | Rounded
+		}
+		if ctx.ExponentLimits && adjusted < ctx.Emin {
+			cond |= Subnormal
+			etiny := ctx.Emin
+			if ctx.Precision > 0 {
+				etiny -= ctx.Precision - 1
+			}
+			if scale > -etiny {
+				c, scale, signalled = ctx.discard(c, scale, scale+etiny)
+				cond |= signalled
+				if signalled&Inexact != 0 {
+					cond |= Underflow
+				}
+				if c.Sign() == 0 {
+					cond |= Clamped
+				}
+			}
+		}
+	}
+	return c, scale, cond
+}
+
+// signal records cond in the flags of the context, and returns an error if any
+// of cond is trapped. num describes the operation.
+func (ctx *Context) signal(fnName, num string, cond Condition) error {
+	ctx.Flags |= cond
+	trapped := cond & ctx.Traps
+	if ctx.Rounding == Exact {
+		trapped |= cond & Inexact
+	}
+	if trapped != 0 {
+		return &NumError{fnName, num, trapped}
+	}
+	return nil
+}
+
+// set sets d to the coefficient c with the given scale, after rounding it
+// according to the context. d is unchanged if an error is returned.
+func (ctx *Context) set(fnName, num string, d *Decimal, c *big.Int, scale int) error {
+	c, scale, cond := ctx.round(c, scale)
+	return ctx.finish(fnName, num, d, c, scale, cond)
+}
+
+// finish sets d to the rounded coefficient c with the given scale, or to the
+// result of overflowing if it does not fit, after signalling cond. d is
+// unchanged if an error is returned.
+func (ctx *Context) finish(fnName, num string, d *Decimal, c *big.Int, scale int, cond Condition) error {
+	var result Decimal
+	if cond&Overflow == 0 && !result.setCoefficient(c, scale) {
+		cond |= Overflow | Inexact | Rounded
+	}
+	if cond&Overflow != 0 {
+		result = ctx.overflow(c.Sign() < 0)
+	}
+	return ctx.setResult(fnName, num, d, result, cond)
+}
+
+// overflow returns the result of an operation that overflowed, with the sign
+// given by negative. It is an infinity, unless the rounding mode rounds towards
+// zero for that sign, in which case it is the largest finite value.
+func (ctx *Context) overflow(negative bool) Decimal {
+	switch {
+	case ctx.Rounding == ToZero,
+		ctx.Rounding == ToPositiveInf && negative,
+		ctx.Rounding == ToNegativeInf && !negative:
+		result := ctx.largest()
+		result.Negative = negative
+		return result
+	}
+	return Decimal{Valid: true, Negative: negative, form: infinite}
+}
+
+// largest returns the largest finite value allowed by the context and by a
+// Decimal.
+func (ctx *Context) largest() Decimal {
+	// The largest Decimal has a maxUint64 integer part, and maxScale nines
+	// after the decimal separator. Its adjusted exponent is 19.
+	scale := maxScale
+	c := new(big.Int).SetUint64(maxUnsignedInt64)
+	c.Mul(c, pow10(scale))
+	c.Add(c, new(big.Int).Sub(pow10(scale), bigOne))
+
+	if ctx.ExponentLimits && ctx.Emax < 19 {
+		// Only nines, the first of them at the position of Emax, and keeping
+		// maxScale digits after any leading zeros.
+		if ctx.Emax < -1 {
+			scale -= ctx.Emax + 1
+		}
+		c.Sub(pow10(ctx.Emax+1+scale), bigOne)
+	}
+	if ctx.Precision > 0 {
+		if n := numDigits(c) - ctx.Precision; n > 0 {
+			c.Quo(c, pow10(n))
+			scale -= n
+		}
+	}
+
+	var result Decimal
+	result.setCoefficient(c, scale)
+	return result
+}
+
+// setResult signals cond, and then sets d to result unless an error is
+// returned.
+func (ctx *Context) setResult(fnName, num string, d *Decimal, result Decimal, cond Condition) error {
+	if err := ctx.signal(fnName, num, cond); err != nil {
+		return err
+	}
+	*d = result
+	return nil
+}
+
+// special handles the operation d1 op d2 if either d1 or d2 is a special
+// value, returning whether it did so.
+func (ctx *Context) special(fnName, op string, d1, d2 *Decimal) (bool, error) {
+	result, cond, ok := specialResult(op, d1, d2)
+	if !ok {
+		return false, nil
+	}
+	return true, ctx.setResult(fnName, d1.String()+" "+op+" "+d2.String(), d1, result, cond)
+}
+
+// operands returns the coefficients of finite d1 and d2 aligned to the same
+// scale, and that scale.
+func operands(d1, d2 *Decimal) (*big.Int, *big.Int, int) {
+	scale := d1.denominatorDigits
+	if d2.denominatorDigits > scale {
+		scale = d2.denominatorDigits
+	}
+	c1, _ := rescale(d1.coefficient(), d1.denominatorDigits, scale, ToZero)
+	c2, _ := rescale(d2.coefficient(), d2.denominatorDigits, scale, ToZero)
+	return c1, c2, scale
+}
+
+// checkValid returns ErrNotValid, and signals InvalidOperation, if either d1
+// or d2 is not Valid.
+func (ctx *Context) checkValid(d1, d2 *Decimal) error {
+	if !d1.Valid || !d2.Valid {
+		ctx.Flags |= InvalidOperation
+		return ErrNotValid
+	}
+	return nil
+}
+
+// AddContext sets d1 to the sum of d1+d2, rounded according to ctx. The exact
+// sum has as many digits after the decimal separator as the longer of d1 and
+// d2. Conditions are recorded in ctx.Flags, and an error is returned if one is
+// trapped, or if either d1 or d2 are flagged as being invalid. d1 is unchanged
+// on error. NaN and infinities are handled as with Add, with a signaling NaN
+// operand signalling InvalidOperation.
+func (d1 *Decimal) AddContext(d2 *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d1, d2); err != nil {
+		return err
+	}
+	if ok, err := ctx.special("AddContext", "+", d1, d2); ok {
+		return err
+	}
+	c1, c2, scale := operands(d1, d2)
+	return ctx.set("AddContext", d1.String()+" + "+d2.String(), d1, c1.Add(c1, c2), scale)
+}
+
+// SubContext sets d1 to the result of d1-d2, rounded according to ctx. It is
+// otherwise the same as AddContext.
+func (d1 *Decimal) SubContext(d2 *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d1, d2); err != nil {
+		return err
+	}
+	if ok, err := ctx.special("SubContext", "-", d1, d2); ok {
+		return err
+	}
+	c1, c2, scale := operands(d1, d2)
+	return ctx.set("SubContext", d1.String()+" - "+d2.String(), d1, c1.Sub(c1, c2), scale)
+}
+
+// MulContext sets d1 to the product of d1*d2, rounded according to ctx. The
+// exact product has as many digits after the decimal separator as d1 and d2
+// combined. It is otherwise the same as AddContext.
+func (d1 *Decimal) MulContext(d2 *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d1, d2); err != nil {
+		return err
+	}
+	if ok, err := ctx.special("MulContext", "*", d1, d2); ok {
+		return err
+	}
+	c := new(big.Int).Mul(d1.coefficient(), d2.coefficient())
+	return ctx.set("MulContext", d1.String()+" * "+d2.String(), d1, c, d1.denominatorDigits+d2.denominatorDigits)
+}
+
+// QuoContext sets d1 to the quotient of d1/d2, rounded according to ctx. An
+// exact quotient is given with as many digits after the decimal separator as d1
+// has more than d2, or as few more as are needed to hold it. Dividing by zero
+// signals DivisionByZero, or InvalidOperation if d1 is also zero. It is
+// otherwise the same as AddContext.
+func (d1 *Decimal) QuoContext(d2 *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d1, d2); err != nil {
+		return err
+	}
+	if ok, err := ctx.special("QuoContext", "/", d1, d2); ok {
+		return err
+	}
+	num := d1.String() + " / " + d2.String()
+	if d2.isZero() {
+		if d1.isZero() {
+			return ctx.setResult("QuoContext", num, d1, Decimal{Valid: true, form: qnan}, InvalidOperation)
+		}
+		inf := Decimal{Valid: true, Negative: d1.Negative != d2.Negative, form: infinite}
+		return ctx.setResult("QuoContext", num, d1, inf, DivisionByZero)
+	}
+
+	q := new(big.Rat).Quo(d1.rat(), d2.rat())
+	c, scale := ctx.quotient(q, d1.denominatorDigits-d2.denominatorDigits)
+	return ctx.set("QuoContext", num, d1, c, scale)
+}
+
+// quotient returns a coefficient and scale for q, ready to be rounded by the
+// context. If q has a finite decimal expansion it is returned exactly, with at
+// least idealScale digits after the decimal separator. Otherwise, enough digits
+// are returned for rounding, followed by a non-zero sticky digit which stands
+// in for those that were discarded.
+func (ctx *Context) quotient(q *big.Rat, idealScale int) (*big.Int, int) {
+	n, m := q.Num(), q.Denom()
+
+	// q terminates if, and only if, the only prime factors of m are 2 and 5.
+	rest, scale := new(big.Int).Set(m), 0
+	for _, p := range []int64{2, 5} {
+		prime, r := big.NewInt(p), new(big.Int)
+		for count := 0; ; count++ {
+			quo, _ := new(big.Int).QuoRem(rest, prime, r)
+			if r.Sign() != 0 {
+				if count > scale {
+					scale = count
+				}
+				break
+			}
+			rest = quo
+		}
+	}
+	if rest.Cmp(bigOne) == 0 {
+		if idealScale > scale {
+			scale = idealScale
+		}
+		c := new(big.Int).Mul(n, pow10(scale))
+		return c.Quo(c, m), scale
+	}
+
+	// One more digit than will be kept is enough, given the sticky digit. The
+	// adjusted exponent of the fractional part of q is at least
+	// numDigits(r)-numDigits(m)-1, where r is the remainder of n/m.
+	scale = maxScale + 1
+	r := new(big.Int).Rem(n, m)
+	if adjusted := numDigits(r) - numDigits(m) - 1; adjusted < 0 {
+		scale -= adjusted
+	}
+	if ctx.Precision > 0 {
+		// The adjusted exponent of q is at least numDigits(n)-numDigits(m)-1.
+		if s := ctx.Precision - (numDigits(n) - numDigits(m) - 1); s < scale {
+			scale = s
+		}
+	}
+	c := new(big.Int).Set(n)
+	d := new(big.Int).Set(m)
+	if scale >= 0 {
+		c.Mul(c, pow10(scale))
+	} else {
+		d.Mul(d, pow10(-scale))
+	}
+	c.Quo(c, d)
+	c.Mul(c, bigTen)
+	if n.Sign() < 0 {
+		c.Sub(c, bigOne)
+	} else {
+		c.Add(c, bigOne)
+	}
+	return c, scale + 1
+}
@@ -0,0 +1,161 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestConditionString(t *testing.T) {
+	tests := map[Condition]string{
+		0:                  "no conditions",
+		Inexact:            "Inexact",
+		Inexact | Rounded:  "Inexact, Rounded",
+		Clamped | Overflow: "Clamped, Overflow",
+		Underflow:          "Underflow",
+	}
+
+	for c, expected := range tests {
+		if c.String() != expected {
+			t.Errorf("'%d': Expected '%s', received '%s'.", c, expected, c.String())
+		}
+	}
+}
+
+func TestContextOperations(t *testing.T) {
+	basic := Context{Precision: 9, Rounding: ToNearestEven, Emin: -99, Emax: 99, ExponentLimits: true}
+
+	tests := []struct {
+		description    string
+		ctx            Context
+		op             string
+		input1, input2 string
+		output         string
+		flags, err     Condition
+	}{
+		{description: "Exact sum keeps the larger scale", ctx: basic, op: "+", input1: "1.10", input2: "2.2", output: "3.30"},
+		{description: "Sum rounded to precision", ctx: basic, op: "+", input1: "123456789", input2: "0.5", output: "123456790.0", flags: Inexact | Rounded},
+		{description: "Sum rounded to even", ctx: basic, op: "+", input1: "123456788", input2: "0.5", output: "123456788.0", flags: Inexact | Rounded},
+		{description: "Trailing zeros rounded away", ctx: basic, op: "+", input1: "1234567.890", input2: "0", output: "1234567.89", flags: Rounded},
+		{description: "Difference", ctx: basic, op: "-", input1: "1.9", input2: "2.1", output: "-0.2"},
+		{description: "Difference crossing zero", ctx: basic, op: "-", input1: "-222.222", input2: "-111.111", output: "-111.111"},
+		{description: "Carry into a new digit", ctx: Context{Precision: 3}, op: "+", input1: "9.99", input2: "0.005", output: "10.0", flags: Inexact | Rounded},
+		{description: "Product rounded to precision", ctx: basic, op: "*", input1: "1.23456789", input2: "1.1", output: "1.35802468", flags: Inexact | Rounded},
+		{description: "Product rounded towards zero", ctx: Context{Precision: 3, Rounding: ToZero}, op: "*", input1: "-2.5", input2: "1.07", output: "-2.67", flags: Inexact | Rounded},
+		{description: "Exact quotient at the ideal scale", ctx: basic, op: "/", input1: "1.00", input2: "2", output: "0.50"},
+		{description: "Exact quotient needing more digits", ctx: basic, op: "/", input1: "1", input2: "8", output: "0.125"},
+		{description: "Exact quotient of integers", ctx: basic, op: "/", input1: "6", input2: "2", output: "3.0"},
+		{description: "Inexact quotient", ctx: basic, op: "/", input1: "2", input2: "3", output: "0.666666667", flags: Inexact | Rounded},
+		{description: "Inexact quotient of a large value", ctx: basic, op: "/", input1: "1000000000", input2: "3", output: "333333333.0", flags: Inexact | Rounded},
+		{description: "Inexact quotient rounding up a carry", ctx: Context{Precision: 2, Rounding: ToPositiveInf}, op: "/", input1: "299", input2: "3", output: "100.0", flags: Inexact | Rounded},
+		{description: "Quotient with the precision of the default context", ctx: DefaultContext, op: "/", input1: "1", input2: "3", output: "0.3333333333333333333", flags: Inexact | Rounded},
+		{description: "Quotient with leading zeros after the decimal separator", ctx: DefaultContext, op: "/", input1: "1", input2: "30", output: "0.03333333333333333333", flags: Inexact | Rounded},
+		{description: "Quotient with a precision of 28", ctx: Context{Precision: 28}, op: "/", input1: "10000000000000000000", input2: "3", output: "3333333333333333333.333333333", flags: Inexact | Rounded},
+		{description: "Quotient with a precision of 28 that a Decimal can not hold", ctx: Context{Precision: 28}, op: "/", input1: "1", input2: "3", output: "0.3333333333333333333", flags: Clamped | Inexact | Rounded},
+		{description: "Unlimited context keeps leading zeros", ctx: Context{}, op: "/", input1: "1", input2: "3000", output: "0.0003333333333333333333", flags: Clamped | Inexact | Rounded},
+		{description: "Unlimited context clamps quotients", ctx: Context{}, op: "/", input1: "-2", input2: "3", output: "-0.6666666666666666667", flags: Clamped | Inexact | Rounded},
+		{description: "Division by zero", ctx: basic, op: "/", input1: "1", input2: "0", output: "Infinity", flags: DivisionByZero},
+		{description: "Division of a negative value by zero", ctx: basic, op: "/", input1: "-1", input2: "0", output: "-Infinity", flags: DivisionByZero},
+		{description: "Trapped division by zero", ctx: DefaultContext, op: "/", input1: "1", input2: "0", flags: DivisionByZero, err: DivisionByZero},
+		{description: "Zero divided by zero", ctx: basic, op: "/", input1: "0", input2: "0", output: "NaN", flags: InvalidOperation},
+		{description: "Trapped zero divided by zero", ctx: DefaultContext, op: "/", input1: "0", input2: "0", flags: InvalidOperation, err: InvalidOperation},
+		{description: "Overflow of Emax", ctx: Context{Precision: 3, Emax: 5, ExponentLimits: true}, op: "*", input1: "1000", input2: "1000", output: "Infinity", flags: Overflow | Inexact | Rounded},
+		{description: "Trapped overflow of Emax", ctx: Context{Precision: 3, Emax: 5, ExponentLimits: true, Traps: Overflow}, op: "*", input1: "1000", input2: "1000", flags: Overflow | Inexact | Rounded, err: Overflow},
+		{description: "Overflow of Emax towards zero", ctx: Context{Precision: 3, Rounding: ToZero, Emax: 5, ExponentLimits: true}, op: "*", input1: "1000", input2: "1000", output: "999000.0", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of Emax towards positive infinity", ctx: Context{Precision: 3, Rounding: ToPositiveInf, Emax: 5, ExponentLimits: true}, op: "*", input1: "1000", input2: "1000", output: "Infinity", flags: Overflow | Inexact | Rounded},
+		{description: "Negative overflow of Emax towards positive infinity", ctx: Context{Precision: 3, Rounding: ToPositiveInf, Emax: 5, ExponentLimits: true}, op: "*", input1: "-1000", input2: "1000", output: "-999000.0", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of Emax towards negative infinity", ctx: Context{Precision: 3, Rounding: ToNegativeInf, Emax: 5, ExponentLimits: true}, op: "*", input1: "1000", input2: "1000", output: "999000.0", flags: Overflow | Inexact | Rounded},
+		{description: "Negative overflow of Emax towards negative infinity", ctx: Context{Precision: 3, Rounding: ToNegativeInf, Emax: 5, ExponentLimits: true}, op: "*", input1: "-1000", input2: "1000", output: "-Infinity", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of an Emax of zero", ctx: Context{Precision: 3, Rounding: ToZero, ExponentLimits: true}, op: "+", input1: "5", input2: "5", output: "9.99", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of a negative Emax", ctx: Context{Rounding: ToZero, Emax: -3, Emin: -30, ExponentLimits: true}, op: "*", input1: "0.1", input2: "0.1", output: "0.009999999999999999999", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of a Decimal", ctx: Context{}, op: "*", input1: "18446744073709551615", input2: "2", output: "Infinity", flags: Overflow | Inexact | Rounded},
+		{description: "Negative overflow of a Decimal", ctx: Context{}, op: "*", input1: "18446744073709551615", input2: "-2", output: "-Infinity", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of a Decimal towards zero", ctx: Context{Rounding: ToZero}, op: "*", input1: "18446744073709551615", input2: "-2", output: "-18446744073709551615.9999999999999999999", flags: Overflow | Inexact | Rounded},
+		{description: "Overflow of a Decimal towards zero with a precision", ctx: Context{Precision: 28, Rounding: ToZero}, op: "*", input1: "18446744073709551615", input2: "2", output: "18446744073709551615.99999999", flags: Overflow | Inexact | Rounded},
+		{description: "Trapped overflow of a Decimal", ctx: DefaultContext, op: "*", input1: "18446744073709551615", input2: "2", flags: Overflow | Inexact | Rounded, err: Overflow},
+		{description: "NaN operand", ctx: DefaultContext, op: "+", input1: "1", input2: "NaN", output: "NaN"},
+		{description: "Signaling NaN operand", ctx: basic, op: "*", input1: "sNaN", input2: "NaN", output: "NaN", flags: InvalidOperation},
+		{description: "Trapped signaling NaN operand", ctx: DefaultContext, op: "-", input1: "1", input2: "sNaN", flags: InvalidOperation, err: InvalidOperation},
+		{description: "Infinite sum", ctx: DefaultContext, op: "+", input1: "-Inf", input2: "1", output: "-Infinity"},
+		{description: "Infinity minus infinity", ctx: basic, op: "-", input1: "Inf", input2: "Inf", output: "NaN", flags: InvalidOperation},
+		{description: "Infinity times zero", ctx: DefaultContext, op: "*", input1: "Inf", input2: "0", flags: InvalidOperation, err: InvalidOperation},
+		{description: "Infinity divided by zero", ctx: DefaultContext, op: "/", input1: "Inf", input2: "0", output: "Infinity"},
+		{description: "Subnormal with an Emin of zero", ctx: Context{Precision: 3, Emax: 9, ExponentLimits: true}, op: "/", input1: "1", input2: "2", output: "0.5", flags: Subnormal},
+		{description: "Exponent limits that do not apply", ctx: Context{Precision: 3}, op: "/", input1: "1", input2: "2", output: "0.5"},
+		{description: "Subnormal", ctx: Context{Precision: 3, Emin: -2, ExponentLimits: true}, op: "/", input1: "1", input2: "1000", output: "0.001", flags: Subnormal},
+		{description: "Underflow", ctx: Context{Precision: 3, Emin: -2, ExponentLimits: true}, op: "/", input1: "1", input2: "2048", output: "0.0005", flags: Subnormal | Underflow | Inexact | Rounded},
+		{description: "Underflow to zero", ctx: Context{Precision: 3, Emin: -2, ExponentLimits: true}, op: "*", input1: "0.00001", input2: "0.1", output: "0.0000", flags: Subnormal | Underflow | Inexact | Rounded | Clamped},
+		{description: "Trapped inexact", ctx: Context{Precision: 3, Traps: Inexact}, op: "/", input1: "1", input2: "3", flags: Inexact | Rounded, err: Inexact},
+		{description: "Trapped rounded", ctx: Context{Precision: 3, Traps: Rounded}, op: "+", input1: "1.000", input2: "0", flags: Rounded, err: Rounded},
+		{description: "Exact rounding", ctx: Context{Precision: 3, Rounding: Exact}, op: "*", input1: "1.11", input2: "1.11", flags: Inexact | Rounded, err: Inexact},
+		{description: "Exact rounding with zeros", ctx: Context{Precision: 3, Rounding: Exact}, op: "*", input1: "1.10", input2: "10", output: "11.0", flags: Rounded},
+	}
+
+	for _, test := range tests {
+		d1, err := ParseDecimal(test.input1)
+		if err != nil {
+			t.Fatalf("%s: Expected success, received error '%v'.", test.description, err)
+		}
+		d2, err := ParseDecimal(test.input2)
+		if err != nil {
+			t.Fatalf("%s: Expected success, received error '%v'.", test.description, err)
+		}
+		before := *d1
+
+		ctx := test.ctx
+		switch test.op {
+		case "+":
+			err = d1.AddContext(d2, &ctx)
+		case "-":
+			err = d1.SubContext(d2, &ctx)
+		case "*":
+			err = d1.MulContext(d2, &ctx)
+		case "/":
+			err = d1.QuoContext(d2, &ctx)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("%s: Expected flags '%v', received '%v'.", test.description, test.flags, ctx.Flags)
+		}
+		if err != nil {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("%s: Expected error '%v', received '%v'.", test.description, test.err, err)
+			}
+			if *d1 != before {
+				t.Errorf("%s: Expected the value to be unchanged on error, received '%s'.", test.description, d1.String())
+			}
+			continue
+		}
+		if test.err != 0 {
+			t.Errorf("%s: Expected error '%v', received '%s'.", test.description, test.err, d1.String())
+			continue
+		}
+		if d1.String() != test.output {
+			t.Errorf("%s: Expected '%s', received '%s'.", test.description, test.output, d1.String())
+		}
+	}
+}
+
+func TestContextFlagsAreSticky(t *testing.T) {
+	ctx := Context{Precision: 3}
+	d, _ := ParseDecimal("1")
+	three, _ := ParseDecimal("3")
+	one, _ := ParseDecimal("1")
+
+	if err := d.QuoContext(three, &ctx); err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if err := d.AddContext(one, &ctx); err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if ctx.Flags != Inexact|Rounded {
+		t.Errorf("Expected flags '%v', received '%v'.", Inexact|Rounded, ctx.Flags)
+	}
+
+	invalid := &Decimal{}
+	if err := d.AddContext(invalid, &ctx); err != ErrNotValid {
+		t.Errorf("Expected error '%v', received '%v'.", ErrNotValid, err)
+	}
+	if ctx.Flags&InvalidOperation == 0 {
+		t.Errorf("Expected InvalidOperation to be flagged, received '%v'.", ctx.Flags)
+	}
+}
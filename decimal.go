@@ -8,7 +8,8 @@ package decimal
 
 import (
 	"fmt"
-	"math"
+	"math/big"
+	"strconv"
 )
 
 // Bounds checking values.
@@ -24,11 +25,14 @@ var DecimalSeparator = '.'
 // ThousandsSeparator is the character to use for a thousands separator.
 var ThousandsSeparator = ','
 
-// Decimal is a representation of a Decimal value.
+// Decimal is a representation of a Decimal value. Besides finite values, a
+// Decimal may hold NaN or an infinity. A Decimal that is not Valid holds no
+// value at all, as with SQL NULL.
 type Decimal struct {
 	Valid, Negative        bool
 	numerator, denominator uint64
 	denominatorDigits      int
+	form                   form
 }
 
 // ParseDecimal converts the string s into a Decimal. A valid Decimal string
@@ -42,9 +46,19 @@ type Decimal struct {
 // DD is zero or more decimal digits (up to the max value for a uint64)
 //
 // NN or DD can be omitted, but not both.
+//
+// The special values "NaN", "sNaN", "Inf" and "Infinity" are also accepted,
+// in any case and with an optional sign.
 func ParseDecimal(s string) (*Decimal, error) {
-	const fnName = "ParseDecimal"
+	if d, ok := parseSpecial(s); ok {
+		return d, nil
+	}
+	return parseDecimal("ParseDecimal", s, DecimalSeparator)
+}
 
+// parseDecimal implements ParseDecimal, using separator as the decimal
+// separator. fnName is reported as the failing function in errors.
+func parseDecimal(fnName, s string, separator rune) (*Decimal, error) {
 	if len(s) == 0 {
 		return nil, syntaxError(fnName, s)
 	}
@@ -66,7 +80,7 @@ func ParseDecimal(s string) (*Decimal, error) {
 		switch {
 		case '0' <= d && d <= '9':
 			v = uint8(d - '0')
-		case d == uint8(DecimalSeparator):
+		case d == uint8(separator):
 			if denominatorDigits != -1 {
 				return nil, syntaxError(fnName, s)
 			}
@@ -104,18 +118,60 @@ func ParseDecimal(s string) (*Decimal, error) {
 	return nil, syntaxError(fnName, s)
 }
 
+// NewFromInt64 returns a new Decimal with the value v.
+func NewFromInt64(v int64) *Decimal {
+	if v < 0 {
+		// Negate v+1 so that minSignedInt64 does not overflow.
+		return &Decimal{Valid: true, Negative: true, numerator: uint64(-(v + 1)) + 1}
+	}
+	return &Decimal{Valid: true, numerator: uint64(v)}
+}
+
+// NewFromUint64 returns a new Decimal with the value v.
+func NewFromUint64(v uint64) *Decimal {
+	return &Decimal{Valid: true, numerator: v}
+}
+
+// New returns a new Decimal with the value unscaled * 10**-scale. For example,
+// New(12345, 2) returns 123.45. As with ParseDecimal, the result has exactly
+// scale digits after the decimal separator. A negative scale multiplies
+// unscaled by a power of ten, and an error is returned if the result overflows,
+// or if scale is less than -10000 or more than 10000.
+func New(unscaled int64, scale int) (*Decimal, error) {
+	decimal := &Decimal{}
+	if !decimal.setCoefficient(big.NewInt(unscaled), scale) {
+		return nil, rangeError("New", strconv.FormatInt(unscaled, 10)+"e"+strconv.Itoa(-scale))
+	}
+	return decimal, nil
+}
+
 // Cmp compares d1 and d2 and returns:
 //
 //   -1 if d1 <  d2
 //    0 if d1 == d2
 //   +1 if d1 >  d2
 //
+// Infinities compare beyond every finite value. NaN is unordered, but so that
+// Cmp is a total order suitable for sorting, a NaN compares equal to any other
+// NaN and less than every other value. Use IsNaN to detect it.
 func (d1 *Decimal) Cmp(d2 *Decimal) (r int) {
+	if d1.form != finite || d2.form != finite {
+		r1, r2 := d1.specialRank(), d2.specialRank()
+		switch {
+		case r1 < r2:
+			return -1
+		case r1 > r2:
+			return 1
+		case r1 != 2:
+			return 0
+		}
+	}
 	if d1.Negative == d2.Negative {
-		if d1.numerator == d2.numerator && d1.denominator == d2.denominator {
+		fraction := cmpFraction(d1, d2)
+		if d1.numerator == d2.numerator && fraction == 0 {
 			return
 		}
-		if d1.numerator > d2.numerator || d1.numerator == d2.numerator && d1.denominator > d2.denominator {
+		if d1.numerator > d2.numerator || d1.numerator == d2.numerator && fraction > 0 {
 			r = 1
 		} else {
 			r = -1
@@ -133,140 +189,131 @@ func (d1 *Decimal) Cmp(d2 *Decimal) (r int) {
 	return
 }
 
-// Add sets d1 to the sum of d1+d2. An error is returned if either d1 or d2
-// are flagged as being invalid, or if the operation would result in d1
-// overflowing. d1 is unchanged on error.
+// Add sets d1 to the sum of d1+d2. The sum has as many digits after the
+// decimal separator as the longer of d1 and d2, so adding 1.50 and 2.5 gives
+// 4.00. An error is returned if either d1 or d2 are flagged as being invalid,
+// or if the operation would result in d1 overflowing. d1 is unchanged on
+// error.
+//
+// If either d1 or d2 is NaN, or both are infinities of opposite sign, d1 is
+// set to NaN. Otherwise, an infinite operand gives an infinite sum.
 func (d1 *Decimal) Add(d2 *Decimal) error {
+	return d1.add("Add", d2, false)
+}
+
+// Sub sets d1 to the result of d1-d2. The result has as many digits after the
+// decimal separator as the longer of d1 and d2. An error is returned if either
+// d1 or d2 are flagged as being invalid, or if the operation would result in
+// d1 overflowing. d1 is unchanged on error. NaN and infinities are handled as
+// with Add.
+func (d1 *Decimal) Sub(d2 *Decimal) error {
+	return d1.add("Sub", d2, true)
+}
+
+// add implements Add, or Sub if subtract is true.
+func (d1 *Decimal) add(fnName string, d2 *Decimal, subtract bool) error {
 	if !d1.Valid || !d2.Valid {
 		return ErrNotValid
 	}
-
-	// Bounds checking.
-	if d1.denominator+d2.denominator < d1.denominator {
-		return rangeError("Add", d1.String()+" + "+d2.String())
-	}
-	if d1.numerator+d2.numerator < d1.numerator {
-		return rangeError("Add", d1.String()+" + "+d2.String())
+	op := "+"
+	if subtract {
+		op = "-"
 	}
-
-	// Work on a copy until we're sure that d1 doesn't overflow.
-	d1copy := *d1
-
-	// Ensure equal "length" denominators.
-	d2Denominator := d2.denominator
-	d2DenomDigits := d2.denominatorDigits
-	if d1copy.denominatorDigits > d2.denominatorDigits {
-		d2Denominator *= uint64(math.Pow10(d1copy.denominatorDigits - d2.denominatorDigits))
-		d2DenomDigits = d1copy.denominatorDigits
-	} else if d2.denominatorDigits > d1copy.denominatorDigits {
-		d1copy.denominator *= uint64(math.Pow10(d2.denominatorDigits - d1copy.denominatorDigits))
-		d1copy.denominatorDigits = d2.denominatorDigits
+	if result, _, ok := specialResult(op, d1, d2); ok {
+		*d1 = result
+		return nil
 	}
 
-	if d1copy.Negative == d2.Negative {
-		d1copy.denominator += d2Denominator
-		d1copy.numerator += d2.numerator
-
-		// Perform a carry, if needed.
-		d1DigitsNew := printedLength(d1copy.denominator)
-		if d1DigitsNew > d2DenomDigits {
-			mod := uint64(math.Pow10(d1copy.denominatorDigits))
-			d1Numerator := d1copy.numerator
-			d1copy.numerator += d1copy.denominator / mod
-			d1copy.denominator %= mod
-
-			// Check for overflow via carry.
-			if d1copy.numerator < d1Numerator {
-				return rangeError("Add", d1.String()+" + "+d2.String())
-			}
-		}
+	c1, c2, scale := operands(d1, d2)
+	if subtract {
+		c1.Sub(c1, c2)
 	} else {
-		neg1, neg2 := d1copy.Negative, d2.Negative
-		d1copy.Negative, d2.Negative = false, false
-		if d1copy.Cmp(d2) >= 0 {
-			d1copy.denominator -= d2Denominator
-			d1copy.numerator -= d2.numerator
-		} else {
-			d1copy.denominator = d2Denominator - d1copy.denominator
-			d1copy.numerator = d2.numerator - d1copy.numerator
-			neg1 = !neg1
-		}
-		d1copy.Negative, d2.Negative = neg1, neg2
+		c1.Add(c1, c2)
 	}
 
-	// Zero is not negative.
-	if d1copy.numerator == 0 && d1copy.denominator == 0 && d1copy.Negative {
-		d1copy.Negative = false
+	// Work on a copy until we're sure that d1 doesn't overflow.
+	var result Decimal
+	if !result.setCoefficient(c1, scale) {
+		return rangeError(fnName, d1.String()+" "+op+" "+d2.String())
 	}
-
-	// Simplify the number, and set d1 to d1copy.
-	d1copy.denominator, d1copy.denominatorDigits = simplifyNumber(d1copy.denominator)
-	*d1 = d1copy
+	*d1 = result
 	return nil
 }
 
-// Sub sets d1 to the result of d1-d2. An error is returned if either d1 or d2
-// are flagged as being invalid, or if the operation would result in d1
-// overflowing. d1 is unchanged on error.
-func (d1 *Decimal) Sub(d2 *Decimal) error {
+// Mul sets d1 to the product of d1*d2. The product is exact, and has as many
+// digits after the decimal separator as d1 and d2 combined. An error is
+// returned if either d1 or d2 are flagged as being invalid, or if the
+// operation would result in d1 overflowing. d1 is unchanged on error.
+//
+// If either d1 or d2 is NaN, or one is an infinity and the other zero, d1 is
+// set to NaN. Otherwise, an infinite operand gives an infinite product.
+func (d1 *Decimal) Mul(d2 *Decimal) error {
 	if !d1.Valid || !d2.Valid {
 		return ErrNotValid
 	}
+	if result, _, ok := specialResult("*", d1, d2); ok {
+		*d1 = result
+		return nil
+	}
 
-	if !d1.Negative && !d2.Negative {
-		// Ensure equal "length" denominators.
-		d2Denominator := d2.denominator
-		if d1.denominatorDigits > d2.denominatorDigits {
-			d2Denominator *= uint64(math.Pow10(d1.denominatorDigits - d2.denominatorDigits))
-		} else if d2.denominatorDigits > d1.denominatorDigits {
-			d1.denominator *= uint64(math.Pow10(d2.denominatorDigits - d1.denominatorDigits))
-			d1.denominatorDigits = d2.denominatorDigits
-		}
-
-		if d1.Cmp(d2) >= 0 {
-			d1Denominator := d1.denominator
-			d1.denominator -= d2.denominator
-			d1.numerator -= d2.numerator
-
-			// Borrow from the numerator if the denominator underflows.
-			if d1.denominator > d1Denominator {
-				d1.denominator = uint64(math.Pow10(d1.denominatorDigits)) - (maxUnsignedInt64 - d1.denominator) - 1
-				d1.numerator--
-			}
-		} else {
-			d1.denominator = d2Denominator - d1.denominator
-			d1.numerator = d2.numerator - d1.numerator
-			d1.Negative = !d1.Negative
-		}
+	c := new(big.Int).Mul(d1.coefficient(), d2.coefficient())
+	if !d1.setCoefficient(c, d1.denominatorDigits+d2.denominatorDigits) {
+		return rangeError("Mul", d1.String()+" * "+d2.String())
+	}
+	return nil
+}
 
-		d1.denominator, d1.denominatorDigits = simplifyNumber(d1.denominator)
-	} else {
-		d2Neg := d2.Negative
-		d2.Negative = d1.Negative
-		err := d1.Add(d2)
-		d2.Negative = d2Neg
-		if err != nil {
-			return err
-		}
+// Quo sets d1 to the quotient of d1/d2, rounded to scale digits after the
+// decimal separator according to mode. An error is returned if either d1 or
+// d2 are flagged as being invalid, if d2 is zero, if rounding is required and
+// mode is Exact, or if the operation would result in d1 overflowing. d1 is
+// unchanged on error.
+//
+// If either d1 or d2 is NaN, or both are infinities, d1 is set to NaN. An
+// infinite d1 gives an infinite quotient, and an infinite d2 gives zero.
+func (d1 *Decimal) Quo(d2 *Decimal, scale int, mode RoundingMode) error {
+	if !d1.Valid || !d2.Valid {
+		return ErrNotValid
+	}
+	if result, _, ok := specialResult("/", d1, d2); ok {
+		*d1 = result
+		return nil
+	}
+	if d2.isZero() {
+		return &NumError{"Quo", d1.String() + " / " + d2.String(), ErrDivisionByZero}
 	}
 
+	q := new(big.Rat).Quo(d1.rat(), d2.rat())
+	if _, err := d1.setRat("Quo", q, scale, mode); err != nil {
+		err.(*NumError).Num = d1.String() + " / " + d2.String()
+		return err
+	}
 	return nil
 }
 
 // String returns the string representation of the Decimal. Thousands
 // separators are not used.
 func (d *Decimal) String() string {
+	return d.string(DecimalSeparator)
+}
+
+// string returns the string representation of the Decimal, using separator as
+// the decimal separator.
+func (d *Decimal) string(separator rune) string {
+	if d.form != finite {
+		return d.specialString()
+	}
 	const fmtString = "%%d%%c%%0%dd"
 	if d.Negative {
-		return fmt.Sprintf("-"+fmt.Sprintf(fmtString, d.denominatorDigits), d.numerator, DecimalSeparator, d.denominator)
+		return fmt.Sprintf("-"+fmt.Sprintf(fmtString, d.denominatorDigits), d.numerator, separator, d.denominator)
 	}
-	return fmt.Sprintf(fmt.Sprintf(fmtString, d.denominatorDigits), d.numerator, DecimalSeparator, d.denominator)
+	return fmt.Sprintf(fmt.Sprintf(fmtString, d.denominatorDigits), d.numerator, separator, d.denominator)
 }
 
 // FormattedString returns the string representation of the Decimal. Thousands
 // separators are used.
 func (d *Decimal) FormattedString() string {
-	if d.numerator < 1000 {
+	if d.form != finite || d.numerator < 1000 {
 		return d.String()
 	}
 
@@ -294,6 +294,30 @@ func TestCmp(t *testing.T) {
 			input2:      "-111.333",
 			result:      greaterThan,
 		},
+		{
+			description: "Same sign, same numerator, fewer denominator digits",
+			input1:      "10.3",
+			input2:      "10.25",
+			result:      greaterThan,
+		},
+		{
+			description: "Same sign, same numerator, more denominator digits",
+			input1:      "-10.25",
+			input2:      "-10.3",
+			result:      greaterThan,
+		},
+		{
+			description: "Same sign, same numerator, same denominator, different digits",
+			input1:      "1.05",
+			input2:      "1.5",
+			result:      lessThan,
+		},
+		{
+			description: "Same sign, same numerator, trailing zeros",
+			input1:      "1.50",
+			input2:      "1.5",
+			result:      equalTo,
+		},
 		{
 			description: "Same sign, larger numerator, same denominator",
 			input1:      "222.222",
@@ -511,6 +535,8 @@ func testOperation(t *testing.T, tests []operationTest, op string) {
 		debugOp = "adding"
 	case "-":
 		debugOp = "subtracting"
+	case "*":
+		debugOp = "multiplying"
 	default:
 		t.Fatalf("Unsupported operation '%s'.", op)
 	}
@@ -532,6 +558,8 @@ func testOperation(t *testing.T, tests []operationTest, op string) {
 			err = d1.Add(d2)
 		case "-":
 			err = d1.Sub(d2)
+		case "*":
+			err = d1.Mul(d2)
 		}
 		if err != nil {
 			if !test.result.shouldFail {
@@ -561,7 +589,7 @@ func TestAdd(t *testing.T) {
 			input1:      "111.111",
 			input2:      "-111.111",
 			result: testResult{
-				output: "0.0",
+				output: "0.000",
 			},
 		},
 		{
@@ -569,7 +597,15 @@ func TestAdd(t *testing.T) {
 			input1:      "-111.111",
 			input2:      "111.111",
 			result: testResult{
-				output: "0.0",
+				output: "0.000",
+			},
+		},
+		{
+			description: "Leading zeros in the denominator are kept",
+			input1:      "0.01",
+			input2:      "0.01",
+			result: testResult{
+				output: "0.02",
 			},
 		},
 		{
@@ -709,37 +745,37 @@ func TestAdd(t *testing.T) {
 			},
 		},
 		{
-			description: "Positive plus positive, denominators carry and simplify",
+			description: "Positive plus positive, denominators carry and keep their scale",
 			input1:      "111.555",
 			input2:      "111.645",
 			result: testResult{
-				output: "223.2",
+				output: "223.200",
 			},
 		},
 		{
-			description: "Negative plus negative, denominators carry and simplify",
+			description: "Negative plus negative, denominators carry and keep their scale",
 			input1:      "-111.555",
 			input2:      "-111.645",
 			result: testResult{
 				negative: true,
-				output:   "-223.2",
+				output:   "-223.200",
 			},
 		},
 		{
-			description: "Positive plus positive, denominators simplify",
+			description: "Positive plus positive, denominators keep their scale",
 			input1:      "111.555",
 			input2:      "111.445",
 			result: testResult{
-				output: "223.0",
+				output: "223.000",
 			},
 		},
 		{
-			description: "Negative plus negative, denominators simplify",
+			description: "Negative plus negative, denominators keep their scale",
 			input1:      "-111.555",
 			input2:      "-111.445",
 			result: testResult{
 				negative: true,
-				output:   "-223.0",
+				output:   "-223.000",
 			},
 		},
 		{
@@ -789,12 +825,20 @@ func TestAdd(t *testing.T) {
 
 func TestSub(t *testing.T) {
 	tests := []operationTest{
+		{
+			description: "Leading zeros in the denominator are kept",
+			input1:      "1000.00",
+			input2:      "999.95",
+			result: testResult{
+				output: "0.05",
+			},
+		},
 		{
 			description: "Positive minus positive, result is zero",
 			input1:      "222.222",
 			input2:      "222.222",
 			result: testResult{
-				output: "0.0",
+				output: "0.000",
 			},
 		},
 		{
@@ -811,7 +855,15 @@ func TestSub(t *testing.T) {
 			input2:      "-111.111",
 			result: testResult{
 				negative: true,
-				output:   "-333.333",
+				output:   "-111.111",
+			},
+		},
+		{
+			description: "Negative minus negative, sign becomes positive",
+			input1:      "-111.111",
+			input2:      "-222.222",
+			result: testResult{
+				output: "111.111",
 			},
 		},
 		{
@@ -857,6 +909,14 @@ func TestSub(t *testing.T) {
 				output:   "-111.110001",
 			},
 		},
+		{
+			description: "Positive minus positive, longer denominator first",
+			input1:      "1.25",
+			input2:      "1.1",
+			result: testResult{
+				output: "0.15",
+			},
+		},
 		{
 			description: "Positive minus positive, denominator borrows from numerator",
 			input1:      "111.111",
@@ -865,12 +925,30 @@ func TestSub(t *testing.T) {
 				output: "110.112",
 			},
 		},
+		{
+			description: "Positive minus positive, fraction borrows from the integer part",
+			input1:      "2.1",
+			input2:      "1.9",
+			result: testResult{
+				output: "0.2",
+			},
+		},
+		{
+			description: "Negative minus positive, scale is kept",
+			input1:      "-1.50",
+			input2:      "1.5",
+			result: testResult{
+				negative: true,
+				output:   "-3.00",
+			},
+		},
 		{
 			description: "Bounds checking the numerator",
 			input1:      "-18446744073709551615.0",
 			input2:      "-1.0",
 			result: testResult{
-				shouldFail: true,
+				negative: true,
+				output:   "-18446744073709551614.0",
 			},
 		},
 		{
@@ -878,13 +956,39 @@ func TestSub(t *testing.T) {
 			input1:      "-18446744073709551615.5",
 			input2:      "-0.5",
 			result: testResult{
-				shouldFail: true,
+				negative: true,
+				output:   "-18446744073709551615.0",
 			},
 		},
 		{
 			description: "Bounds checking the denominator",
 			input1:      "-0.18446744073709551615",
 			input2:      "-0.00000000000000000001",
+			result: testResult{
+				negative: true,
+				output:   "-0.18446744073709551614",
+			},
+		},
+		{
+			description: "Bounds checking the numerator, overflow",
+			input1:      "-18446744073709551615.0",
+			input2:      "1.0",
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+		{
+			description: "Bounds checking the numerator via carry, overflow",
+			input1:      "-18446744073709551615.5",
+			input2:      "0.5",
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+		{
+			description: "Bounds checking the denominator, overflow",
+			input1:      "-0.18446744073709551615",
+			input2:      "0.00000000000000000001",
 			result: testResult{
 				shouldFail: true,
 			},
@@ -931,3 +1035,266 @@ func TestFormattedString(t *testing.T) {
 		}
 	}
 }
+
+func TestNewFromInt64(t *testing.T) {
+	tests := map[int64]string{
+		0:                    "0.0",
+		1:                    "1.0",
+		-1:                   "-1.0",
+		12345:                "12345.0",
+		-12345:               "-12345.0",
+		9223372036854775807:  "9223372036854775807.0",
+		-9223372036854775808: "-9223372036854775808.0",
+	}
+
+	for input, output := range tests {
+		d := NewFromInt64(input)
+		if !d.Valid {
+			t.Errorf("%d: Expected valid result.", input)
+		}
+		if d.Negative != (input < 0) {
+			t.Errorf("%d: Expected Negative to be %t.", input, input < 0)
+		}
+		if d.String() != output {
+			t.Errorf("%d: Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+}
+
+func TestNewFromUint64(t *testing.T) {
+	tests := map[uint64]string{
+		0:                    "0.0",
+		12345:                "12345.0",
+		18446744073709551615: "18446744073709551615.0",
+	}
+
+	for input, output := range tests {
+		d := NewFromUint64(input)
+		if !d.Valid || d.Negative {
+			t.Errorf("%d: Expected a valid, positive result.", input)
+		}
+		if d.String() != output {
+			t.Errorf("%d: Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	type newTest struct {
+		unscaled   int64
+		scale      int
+		shouldFail bool
+		negative   bool
+		output     string
+	}
+
+	tests := []newTest{
+		{unscaled: 12345, scale: 2, output: "123.45"},
+		{unscaled: -12345, scale: 2, negative: true, output: "-123.45"},
+		{unscaled: 12345, scale: 0, output: "12345.0"},
+		{unscaled: 100, scale: 2, output: "1.00"},
+		{unscaled: 5, scale: 4, output: "0.0005"},
+		{unscaled: 0, scale: 2, output: "0.00"},
+		{unscaled: 12345, scale: -2, output: "1234500.0"},
+		{unscaled: -9223372036854775808, scale: 0, negative: true, output: "-9223372036854775808.0"},
+		{unscaled: -9223372036854775808, scale: 19, negative: true, output: "-0.9223372036854775808"},
+		{unscaled: -9223372036854775808, scale: 25, negative: true, output: "-0.0000009223372036854775808"},
+		{unscaled: 1844674407370955161, scale: -1, output: "18446744073709551610.0"},
+		{unscaled: 1844674407370955162, scale: -1, shouldFail: true},
+		{unscaled: 9223372036854775807, scale: -2, shouldFail: true},
+		{unscaled: 1, scale: 1 << 20, shouldFail: true},
+		{unscaled: 0, scale: -1 << 40, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, err := New(test.unscaled, test.scale)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("New(%d, %d): expected success, received error '%v'.", test.unscaled, test.scale, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("New(%d, %d): expected failure.", test.unscaled, test.scale)
+			continue
+		}
+		if !d.Valid || d.Negative != test.negative {
+			t.Errorf("New(%d, %d): expected Valid and Negative %t.", test.unscaled, test.scale, test.negative)
+		}
+		if d.String() != test.output {
+			t.Errorf("New(%d, %d): expected '%s', received '%s'.", test.unscaled, test.scale, test.output, d.String())
+		}
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []operationTest{
+		{
+			description: "Positive times positive",
+			input1:      "1.5",
+			input2:      "2.25",
+			result: testResult{
+				output: "3.375",
+			},
+		},
+		{
+			description: "Positive times negative",
+			input1:      "1.5",
+			input2:      "-2",
+			result: testResult{
+				negative: true,
+				output:   "-3.0",
+			},
+		},
+		{
+			description: "Negative times negative",
+			input1:      "-0.1",
+			input2:      "-0.1",
+			result: testResult{
+				output: "0.01",
+			},
+		},
+		{
+			description: "Negative times zero, result is not negative",
+			input1:      "-123.45",
+			input2:      "0.0",
+			result: testResult{
+				output: "0.000",
+			},
+		},
+		{
+			description: "Exchange rate with ten digits",
+			input1:      "1234.56",
+			input2:      "1.0842310000",
+			result: testResult{
+				output: "1338.548223360000",
+			},
+		},
+		{
+			description: "Bounds checking the numerator",
+			input1:      "9223372036854775808",
+			input2:      "2",
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+		{
+			description: "Bounds checking the denominator",
+			input1:      "0.99999999999",
+			input2:      "0.999999999",
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+	}
+
+	testOperation(t, tests, "*")
+}
+
+func TestQuo(t *testing.T) {
+	type quoTest struct {
+		description, input1, input2 string
+		scale                       int
+		mode                        RoundingMode
+		result                      testResult
+	}
+
+	tests := []quoTest{
+		{
+			description: "Exact division",
+			input1:      "10",
+			input2:      "4",
+			scale:       2,
+			mode:        Exact,
+			result: testResult{
+				output: "2.50",
+			},
+		},
+		{
+			description: "Rounded division",
+			input1:      "1",
+			input2:      "3",
+			scale:       4,
+			mode:        ToNearestEven,
+			result: testResult{
+				output: "0.3333",
+			},
+		},
+		{
+			description: "Rounded division, negative result",
+			input1:      "-2",
+			input2:      "3",
+			scale:       4,
+			mode:        ToNearestEven,
+			result: testResult{
+				negative: true,
+				output:   "-0.6667",
+			},
+		},
+		{
+			description: "Inverting an exchange rate",
+			input1:      "1",
+			input2:      "1.084231",
+			scale:       10,
+			mode:        ToNearestEven,
+			result: testResult{
+				output: "0.9223126806",
+			},
+		},
+		{
+			description: "Inexact division",
+			input1:      "1",
+			input2:      "3",
+			scale:       4,
+			mode:        Exact,
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+		{
+			description: "Division by zero",
+			input1:      "1",
+			input2:      "0.00",
+			scale:       4,
+			mode:        ToNearestEven,
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+		{
+			description: "Bounds checking the numerator",
+			input1:      "18446744073709551615",
+			input2:      "0.5",
+			scale:       0,
+			mode:        ToNearestEven,
+			result: testResult{
+				shouldFail: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		d1, _ := ParseDecimal(test.input1)
+		d2, _ := ParseDecimal(test.input2)
+		before := d1.String()
+		err := d1.Quo(d2, test.scale, test.mode)
+		if err != nil {
+			if !test.result.shouldFail {
+				t.Errorf("%s (dividing '%s' by '%s'): expected success, received error '%v'.", test.description, test.input1, test.input2, err)
+			} else if d1.String() != before {
+				t.Errorf("%s (dividing '%s' by '%s'): expected d1 to be unchanged, received '%s'.", test.description, test.input1, test.input2, d1.String())
+			}
+			continue
+		}
+		if test.result.shouldFail {
+			t.Errorf("%s (dividing '%s' by '%s'): expected failure.", test.description, test.input1, test.input2)
+			continue
+		}
+		if d1.Negative != test.result.negative {
+			t.Errorf("%s (dividing '%s' by '%s'): expected Negative to be %t.", test.description, test.input1, test.input2, test.result.negative)
+		}
+		if d1.String() != test.result.output {
+			t.Errorf("%s (dividing '%s' by '%s'): expected '%s', received '%s'.", test.description, test.input1, test.input2, test.result.output, d1.String())
+		}
+	}
+}
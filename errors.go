@@ -15,6 +15,20 @@ var ErrSyntax = errors.New("invalid syntax")
 // ErrNotValid indicates that a value has Valid set to false.
 var ErrNotValid = errors.New("value is not valid")
 
+// ErrInexact indicates that a value can not be represented without
+// discarding digits, and rounding was not permitted.
+var ErrInexact = errors.New("value would be rounded")
+
+// ErrNotFinite indicates that a value is NaN or an infinity.
+var ErrNotFinite = errors.New("value is not finite")
+
+// ErrDivisionByZero indicates that a division had a divisor of zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ErrAvroSchema indicates that an Avro decimal schema has an invalid precision
+// or scale.
+var ErrAvroSchema = errors.New("invalid Avro decimal precision or scale")
+
 // NumError records a failed conversion.
 type NumError struct {
 	Func string // the failing function
@@ -33,3 +47,7 @@ func syntaxError(fn, str string) *NumError {
 func rangeError(fn, str string) *NumError {
 	return &NumError{fn, str, ErrRange}
 }
+
+func inexactError(fn, str string) *NumError {
+	return &NumError{fn, str, ErrInexact}
+}
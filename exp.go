@@ -0,0 +1,136 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// The functions in this file are correctly rounded according to the context.
+// Each is approximated with big.Float, with a relative error of less than
+// 2**-p where p is the number of bits needed for the context precision plus a
+// few more, and the approximation is repeated with twice as many bits until the
+// error bound no longer straddles a rounding boundary.
+
+// Exp sets d to e**d, correctly rounded according to ctx. e**0 is exactly 1,
+// and every other finite result is inexact. e**-Inf is 0 and e**Inf is Inf. d
+// is unchanged if an error is returned.
+func (d *Decimal) Exp(ctx *Context) error {
+	if err := ctx.checkValid(d, d); err != nil {
+		return err
+	}
+	num := d.String()
+	if result, cond, ok := propagateNaN(d, d); ok {
+		return ctx.setResult("Exp", num, d, result, cond)
+	}
+	switch {
+	case d.IsInf(1):
+		return ctx.setResult("Exp", num, d, *d, 0)
+	case d.IsInf(-1):
+		return ctx.setResult("Exp", num, d, Decimal{Valid: true}, 0)
+	case d.isZero():
+		return ctx.setResult("Exp", num, d, Decimal{Valid: true, numerator: 1}, 0)
+	}
+
+	x := d.rat()
+	return ctx.setApprox("Exp", num, d, func(prec uint) *big.Float {
+		return bigExp(new(big.Float).SetPrec(prec+2*guardBits).SetRat(x), prec)
+	}, nil)
+}
+
+// Ln sets d to the natural logarithm of d, correctly rounded according to ctx.
+// Ln(1) is exactly 0, and every other finite result is inexact. Ln(0) is -Inf,
+// Ln(Inf) is Inf, and the logarithm of a negative value is an invalid
+// operation with a NaN result. d is unchanged if an error is returned.
+func (d *Decimal) Ln(ctx *Context) error {
+	return d.log("Ln", nil, ctx)
+}
+
+// Log10 sets d to the base 10 logarithm of d, correctly rounded according to
+// ctx. The result is exact if d is a power of ten, such as 0.001, and is
+// inexact otherwise. Special cases are as for Ln.
+func (d *Decimal) Log10(ctx *Context) error {
+	return d.log("Log10", NewFromInt64(10), ctx)
+}
+
+// Log sets d to the logarithm of d to the given base, correctly rounded
+// according to ctx. The result is exact if it is a simple fraction, such as
+// the logarithm of 8 to base 4. Special cases are as for Ln, with the signs of
+// infinite results reversed for bases below 1. A base that is not finite, not
+// positive, or equal to 1 is an invalid operation with a NaN result. d is
+// unchanged if an error is returned.
+func (d *Decimal) Log(base *Decimal, ctx *Context) error {
+	return d.log("Log", base, ctx)
+}
+
+// log implements Ln, Log10 and Log, with a nil base for the natural logarithm.
+func (d *Decimal) log(fnName string, base *Decimal, ctx *Context) error {
+	b := d
+	if base != nil {
+		b = base
+	}
+	if err := ctx.checkValid(d, b); err != nil {
+		return err
+	}
+	num := d.String()
+	if fnName == "Log" {
+		num += ", " + base.String()
+	}
+	if result, cond, ok := propagateNaN(d, b); ok {
+		return ctx.setResult(fnName, num, d, result, cond)
+	}
+
+	// Bases below 1 reverse the sign of the result.
+	reversed := false
+	if base != nil {
+		one := &Decimal{Valid: true, numerator: 1}
+		if base.form != finite || base.Negative || base.isZero() || base.Cmp(one) == 0 {
+			return ctx.setResult(fnName, num, d, Decimal{Valid: true, form: qnan}, InvalidOperation)
+		}
+		reversed = base.Cmp(one) < 0
+	}
+	switch {
+	case d.Negative:
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, form: qnan}, InvalidOperation)
+	case d.isZero():
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, Negative: !reversed, form: infinite}, 0)
+	case d.form == infinite:
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, Negative: reversed, form: infinite}, 0)
+	case d.Cmp(&Decimal{Valid: true, numerator: 1}) == 0:
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true}, 0)
+	}
+
+	// The base 10 logarithm of a power of ten is exact, however large the
+	// power, without approximating it.
+	if base != nil && base.Cmp(NewFromInt64(10)) == 0 {
+		c := d.coefficient()
+		if k := numDigits(c) - 1; c.Cmp(pow10(k)) == 0 {
+			return ctx.set(fnName, num, d, big.NewInt(int64(k-d.denominatorDigits)), 0)
+		}
+	}
+
+	x := d.rat()
+	if base == nil {
+		return ctx.setApprox(fnName, num, d, func(prec uint) *big.Float {
+			return bigLn(new(big.Float).SetPrec(prec+2*guardBits).SetRat(x), prec)
+		}, nil)
+	}
+
+	// The quotient of two logarithms, each with a relative error of less
+	// than 2**-(prec+2), has a relative error of less than 2**-prec.
+	y := base.rat()
+	f := func(prec uint) *big.Float {
+		wp := prec + 2*guardBits
+		r := bigLn(new(big.Float).SetPrec(wp).SetRat(x), prec+2)
+		return r.Quo(r, bigLn(new(big.Float).SetPrec(wp).SetRat(y), prec+2))
+	}
+	exact := func(candidate *big.Rat) bool {
+		// base**(p/q) == x, so x**q == base**p.
+		p, q := candidate.Num(), candidate.Denom()
+		if limit := big.NewInt(64); p.CmpAbs(limit) > 0 || q.Cmp(limit) > 0 {
+			return false
+		}
+		return ratPow(x, q).Cmp(ratPow(y, p)) == 0
+	}
+	return ctx.setApprox(fnName, num, d, f, exact)
+}
@@ -0,0 +1,115 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpAndLogarithms(t *testing.T) {
+	basic := Context{Precision: 16}
+
+	tests := []struct {
+		ctx         Context
+		fn          string
+		input, base string
+		output      string
+		flags, err  Condition
+	}{
+		{ctx: basic, fn: "Exp", input: "1", output: "2.718281828459045", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Exp", input: "-1", output: "0.3678794411714423", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Exp", input: "0.0000001", output: "1.000000100000005", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 10, Rounding: ToZero}, fn: "Exp", input: "43.5", output: "7794889495000000000.0", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Exp", input: "43.5", output: "7794889495725306399.5936237456571727415", flags: Clamped | Inexact | Rounded},
+		{ctx: Context{}, fn: "Exp", input: "2", output: "7.3890560989306502272", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Exp", input: "-50", output: "0.0000000000000000000001928749847963918", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Exp", input: "50", output: "Infinity", flags: Overflow | Inexact | Rounded},
+		{ctx: DefaultContext, fn: "Exp", input: "1000000", flags: Overflow | Inexact | Rounded, err: Overflow},
+		{ctx: basic, fn: "Exp", input: "0.00", output: "1.0"},
+		{ctx: basic, fn: "Exp", input: "-Inf", output: "0.0"},
+		{ctx: basic, fn: "Exp", input: "Inf", output: "Infinity"},
+
+		{ctx: basic, fn: "Ln", input: "2", output: "0.6931471805599453", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Ln", input: "0.0000001", output: "-16.11809565095832", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Ln", input: "18446744073709551615", output: "44.3614195558364998026", flags: Clamped | Inexact | Rounded},
+		{ctx: Context{}, fn: "Ln", input: "1.0000000000000000001", output: "0.0000000000000000001000000000000000000", flags: Clamped | Inexact | Rounded},
+		{ctx: Context{Precision: 10, Rounding: ToNegativeInf}, fn: "Ln", input: "0.5", output: "-0.6931471806", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Ln", input: "1.000", output: "0.0"},
+		{ctx: basic, fn: "Ln", input: "0", output: "-Infinity"},
+		{ctx: basic, fn: "Ln", input: "Inf", output: "Infinity"},
+		{ctx: basic, fn: "Ln", input: "-1", output: "NaN", flags: InvalidOperation},
+		{ctx: DefaultContext, fn: "Ln", input: "-Inf", flags: InvalidOperation, err: InvalidOperation},
+
+		{ctx: basic, fn: "Log10", input: "2", output: "0.3010299956639812", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Log10", input: "2", output: "0.3010299956639811952", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Log10", input: "1000", output: "3.0"},
+		{ctx: Context{Precision: 5, Rounding: ToZero}, fn: "Log10", input: "0.001", output: "-3.0"},
+		{ctx: basic, fn: "Log10", input: "100.000", output: "2.0"},
+		{ctx: basic, fn: "Log10", input: "0.0000000000000000001", output: "-19.0"},
+		{ctx: Context{Precision: 19, Rounding: ToNegativeInf}, fn: "Log10", input: "0." + strings.Repeat("0", 64) + "1", output: "-65.0"},
+		{ctx: Context{Precision: 19, Rounding: ToPositiveInf}, fn: "Log10", input: "0." + strings.Repeat("0", 99) + "1", output: "-100.0"},
+		{ctx: Context{Precision: 19, Rounding: AwayFromZero}, fn: "Log10", input: "1" + strings.Repeat("0", 19), output: "19.0"},
+		{ctx: Context{Precision: 1, Rounding: ToZero}, fn: "Log10", input: "0." + strings.Repeat("0", 99) + "1", output: "-100.0", flags: Rounded},
+		{ctx: basic, fn: "Log", input: "0.00001", base: "10.0", output: "-5.0"},
+		{ctx: basic, fn: "Log10", input: "0", output: "-Infinity"},
+		{ctx: basic, fn: "Log10", input: "sNaN", output: "NaN", flags: InvalidOperation},
+
+		{ctx: basic, fn: "Log", input: "7", base: "3", output: "1.771243749161422", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Log", input: "8", base: "4", output: "1.5"},
+		{ctx: Context{Precision: 5, Rounding: ToPositiveInf}, fn: "Log", input: "0.25", base: "2", output: "-2.0"},
+		{ctx: basic, fn: "Log", input: "100", base: "0.1", output: "-2.0"},
+		{ctx: basic, fn: "Log", input: "0", base: "0.5", output: "Infinity"},
+		{ctx: basic, fn: "Log", input: "Inf", base: "0.5", output: "-Infinity"},
+		{ctx: basic, fn: "Log", input: "2", base: "1", output: "NaN", flags: InvalidOperation},
+		{ctx: basic, fn: "Log", input: "2", base: "-2", output: "NaN", flags: InvalidOperation},
+		{ctx: basic, fn: "Log", input: "2", base: "Inf", output: "NaN", flags: InvalidOperation},
+		{ctx: basic, fn: "Log", input: "2", base: "NaN", output: "NaN"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		before := *d
+
+		ctx := test.ctx
+		switch test.fn {
+		case "Exp":
+			err = d.Exp(&ctx)
+		case "Ln":
+			err = d.Ln(&ctx)
+		case "Log10":
+			err = d.Log10(&ctx)
+		case "Log":
+			base, _ := ParseDecimal(test.base)
+			err = d.Log(base, &ctx)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("%s('%s'): Expected flags '%v', received '%v'.", test.fn, test.input, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("%s('%s'): Expected error '%v', received '%v'.", test.fn, test.input, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("%s('%s'): Expected the value to be unchanged on error, received '%s'.", test.fn, test.input, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("%s('%s'): Expected '%s', received '%s' (error '%v').", test.fn, test.input, test.output, d.String(), err)
+		}
+	}
+
+	ctx := DefaultContext
+	if err := (&Decimal{}).Ln(&ctx); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+	if err := NewFromInt64(2).Log(&Decimal{}, &ctx); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package finance implements time value of money calculations on Decimals.
+//
+// Rates are periodic rates given as fractions, so a 6% annual rate paid
+// monthly is a rate of 0.005 over 12 periods per year. Intermediate values
+// are computed exactly, and only results are rounded, to a caller supplied
+// scale and rounding mode.
+package finance
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/timewasted/go-decimal"
+)
+
+// ErrPeriods indicates that a number of periods is out of range. At most
+// maxPeriods periods are allowed.
+var ErrPeriods = errors.New("finance: number of periods out of range")
+
+// maxPeriods bounds the number of periods, so that hostile input can not force
+// the computation of enormous exact powers, or of enormous schedules. It allows
+// for over 800 years of monthly periods.
+const maxPeriods = 10000
+
+// ErrRate indicates that a rate is -1 or less, which would leave nothing to
+// compound.
+var ErrRate = errors.New("finance: rate must be greater than -1")
+
+// check returns an error if d is not Valid, or is NaN or an infinity.
+func check(d *decimal.Decimal) error {
+	if !d.Valid {
+		return decimal.ErrNotValid
+	}
+	if !d.IsFinite() {
+		return decimal.ErrNotFinite
+	}
+	return nil
+}
+
+// growth returns (1+rate)**periods, computed exactly. ErrPeriods is returned
+// if periods is more than maxPeriods.
+func growth(rate *decimal.Decimal, periods int) (*big.Rat, error) {
+	if err := check(rate); err != nil {
+		return nil, err
+	}
+	if periods > maxPeriods {
+		return nil, ErrPeriods
+	}
+	base := new(big.Rat).Add(big.NewRat(1, 1), rate.Rat())
+	if base.Sign() <= 0 {
+		return nil, ErrRate
+	}
+	return powRat(base, periods), nil
+}
+
+// powRat returns x**n for n >= 0, using repeated squaring.
+func powRat(x *big.Rat, n int) *big.Rat {
+	result := big.NewRat(1, 1)
+	square := new(big.Rat).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, square)
+		}
+		square.Mul(square, square)
+	}
+	return result
+}
+
+// round returns a new Decimal holding r, rounded to scale digits after the
+// decimal separator according to mode.
+func round(r *big.Rat, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	d := &decimal.Decimal{}
+	if _, err := d.SetRat(r, scale, mode); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// FutureValue returns the value of pv after compounding at rate for the given
+// number of periods, pv*(1+rate)**periods, rounded to scale digits after the
+// decimal separator according to mode.
+func FutureValue(pv, rate *decimal.Decimal, periods, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	if err := check(pv); err != nil {
+		return nil, err
+	}
+	if periods < 0 {
+		return nil, ErrPeriods
+	}
+	g, err := growth(rate, periods)
+	if err != nil {
+		return nil, err
+	}
+	return round(g.Mul(g, pv.Rat()), scale, mode)
+}
+
+// PresentValue returns the value today of fv received after the given number
+// of periods, discounted at rate, fv/(1+rate)**periods, rounded to scale
+// digits after the decimal separator according to mode.
+func PresentValue(fv, rate *decimal.Decimal, periods, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	if err := check(fv); err != nil {
+		return nil, err
+	}
+	if periods < 0 {
+		return nil, ErrPeriods
+	}
+	g, err := growth(rate, periods)
+	if err != nil {
+		return nil, err
+	}
+	return round(g.Quo(fv.Rat(), g), scale, mode)
+}
+
+// payment returns the exact payment that repays principal at rate over the
+// given number of periods.
+func payment(principal, rate *decimal.Decimal, periods int) (*big.Rat, error) {
+	if err := check(principal); err != nil {
+		return nil, err
+	}
+	if periods <= 0 {
+		return nil, ErrPeriods
+	}
+	g, err := growth(rate, periods)
+	if err != nil {
+		return nil, err
+	}
+	if rate.Rat().Sign() == 0 {
+		return new(big.Rat).Quo(principal.Rat(), big.NewRat(int64(periods), 1)), nil
+	}
+
+	// principal * rate * g / (g - 1)
+	pmt := new(big.Rat).Mul(principal.Rat(), rate.Rat())
+	pmt.Mul(pmt, g)
+	return pmt.Quo(pmt, g.Sub(g, big.NewRat(1, 1))), nil
+}
+
+// Payment returns the level payment that repays principal, with interest at
+// rate, over the given number of periods (the PMT function of spreadsheets),
+// rounded to scale digits after the decimal separator according to mode.
+// Payments are at the end of each period, and a positive principal gives a
+// positive payment.
+func Payment(principal, rate *decimal.Decimal, periods, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	pmt, err := payment(principal, rate, periods)
+	if err != nil {
+		return nil, err
+	}
+	return round(pmt, scale, mode)
+}
+
+// Period is one row of an amortization schedule.
+type Period struct {
+	Number    int             // the period, starting at 1
+	Payment   decimal.Decimal // the total paid this period
+	Interest  decimal.Decimal // the part of Payment that is interest
+	Principal decimal.Decimal // the part of Payment that repays principal
+	Balance   decimal.Decimal // the principal outstanding after Payment
+}
+
+// AmortizationSchedule returns the schedule for repaying principal, with
+// interest at rate, over the given number of periods. Every amount is rounded
+// to scale digits after the decimal separator according to mode.
+//
+// Each period's interest is the outstanding balance times rate, rounded. The
+// payment is the rounded level payment given by Payment, except for the final
+// period, whose payment is adjusted to absorb the residual left by rounding so
+// that the final balance is exactly zero.
+func AmortizationSchedule(principal, rate *decimal.Decimal, periods, scale int, mode decimal.RoundingMode) ([]Period, error) {
+	pmt, err := Payment(principal, rate, periods, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	start, err := round(principal.Rat(), scale, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every amount below is held at scale, so only the interest needs to be
+	// rounded.
+	r, payment, balance := rate.Rat(), pmt.Rat(), start.Rat()
+	schedule := make([]Period, periods)
+	for i := range schedule {
+		row := &schedule[i]
+		row.Number = i + 1
+
+		if _, err := row.Interest.SetRat(new(big.Rat).Mul(balance, r), scale, mode); err != nil {
+			return nil, err
+		}
+		interest := row.Interest.Rat()
+
+		repaid := new(big.Rat).Sub(payment, interest)
+		if row.Number == periods {
+			repaid.Set(balance)
+		}
+		balance.Sub(balance, repaid)
+
+		if _, err := row.Payment.SetRat(new(big.Rat).Add(repaid, interest), scale, mode); err != nil {
+			return nil, err
+		}
+		if _, err := row.Principal.SetRat(repaid, scale, mode); err != nil {
+			return nil, err
+		}
+		if _, err := row.Balance.SetRat(balance, scale, mode); err != nil {
+			return nil, err
+		}
+	}
+	return schedule, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"testing"
+
+	"github.com/timewasted/go-decimal"
+)
+
+func parse(t *testing.T, s string) *decimal.Decimal {
+	d, err := decimal.ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("'%s': Expected success, received error '%v'.", s, err)
+	}
+	return d
+}
+
+type tvmTest struct {
+	description, amount, rate string
+	periods                   int
+	shouldFail                bool
+	output                    string
+}
+
+func testTVM(t *testing.T, tests []tvmTest, fn func(amount, rate *decimal.Decimal, periods, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error)) {
+	for _, test := range tests {
+		result, err := fn(parse(t, test.amount), parse(t, test.rate), test.periods, 2, decimal.ToNearestEven)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("%s: Expected success, received error '%v'.", test.description, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("%s: Expected failure, received '%s'.", test.description, result.String())
+			continue
+		}
+		if result.String() != test.output {
+			t.Errorf("%s: Expected '%s', received '%s'.", test.description, test.output, result.String())
+		}
+	}
+}
+
+func TestFutureValue(t *testing.T) {
+	tests := []tvmTest{
+		{description: "Annual compounding", amount: "1000", rate: "0.05", periods: 10, output: "1628.89"},
+		{description: "Monthly compounding", amount: "1000", rate: "0.005", periods: 120, output: "1819.40"},
+		{description: "No periods", amount: "1000", rate: "0.05", periods: 0, output: "1000.00"},
+		{description: "Zero rate", amount: "1000", rate: "0", periods: 10, output: "1000.00"},
+		{description: "Negative rate", amount: "1000", rate: "-0.1", periods: 2, output: "810.00"},
+		{description: "Negative periods", amount: "1000", rate: "0.05", periods: -1, shouldFail: true},
+		{description: "Most periods", amount: "1000", rate: "0.001", periods: 10000, output: "21916681.34"},
+		{description: "Too many periods", amount: "1000", rate: "0.05", periods: 1000000000, shouldFail: true},
+		{description: "Rate of -100%", amount: "1000", rate: "-1", periods: 1, shouldFail: true},
+		{description: "Infinite rate", amount: "1000", rate: "Inf", periods: 1, shouldFail: true},
+		{description: "NaN amount", amount: "NaN", rate: "0.05", periods: 1, shouldFail: true},
+	}
+
+	testTVM(t, tests, FutureValue)
+}
+
+func TestPresentValue(t *testing.T) {
+	tests := []tvmTest{
+		{description: "Annual discounting", amount: "1628.89", rate: "0.05", periods: 10, output: "1000.00"},
+		{description: "Monthly discounting", amount: "10000", rate: "0.005", periods: 60, output: "7413.72"},
+		{description: "No periods", amount: "1000", rate: "0.05", periods: 0, output: "1000.00"},
+		{description: "Rate of -100%", amount: "1000", rate: "-1", periods: 1, shouldFail: true},
+	}
+
+	testTVM(t, tests, PresentValue)
+}
+
+func TestPayment(t *testing.T) {
+	tests := []tvmTest{
+		{description: "30 year mortgage", amount: "100000", rate: "0.005", periods: 360, output: "599.55"},
+		{description: "Short loan", amount: "1000", rate: "0.01", periods: 3, output: "340.02"},
+		{description: "Zero rate", amount: "1000", rate: "0", periods: 3, output: "333.33"},
+		{description: "No periods", amount: "1000", rate: "0.01", periods: 0, shouldFail: true},
+		{description: "Too many periods", amount: "1000", rate: "0.01", periods: 1000000000, shouldFail: true},
+	}
+
+	testTVM(t, tests, Payment)
+}
+
+func TestAmortizationSchedule(t *testing.T) {
+	schedule, err := AmortizationSchedule(parse(t, "1000"), parse(t, "0.01"), 3, 2, decimal.ToNearestEven)
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+
+	expected := [][4]string{
+		{"340.02", "10.00", "330.02", "669.98"},
+		{"340.02", "6.70", "333.32", "336.66"},
+		{"340.03", "3.37", "336.66", "0.00"},
+	}
+	if len(schedule) != len(expected) {
+		t.Fatalf("Expected %d periods, received %d.", len(expected), len(schedule))
+	}
+	for i, row := range schedule {
+		received := [4]string{row.Payment.String(), row.Interest.String(), row.Principal.String(), row.Balance.String()}
+		if row.Number != i+1 || received != expected[i] {
+			t.Errorf("Period %d: Expected %v, received %d %v.", i+1, expected[i], row.Number, received)
+		}
+	}
+}
+
+func TestAmortizationScheduleTooManyPeriods(t *testing.T) {
+	if _, err := AmortizationSchedule(parse(t, "1000"), parse(t, "0.01"), 1000000000, 2, decimal.ToNearestEven); err != ErrPeriods {
+		t.Errorf("Expected error '%v', received '%v'.", ErrPeriods, err)
+	}
+}
+
+func TestAmortizationScheduleMortgage(t *testing.T) {
+	principal := parse(t, "250000")
+	schedule, err := AmortizationSchedule(principal, parse(t, "0.0054166667"), 360, 2, decimal.ToNearestAway)
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+
+	first, last := schedule[0], schedule[len(schedule)-1]
+	if first.Payment.String() != "1580.17" || first.Interest.String() != "1354.17" || first.Principal.String() != "226.00" {
+		t.Errorf("First period: Expected 1580.17 = 1354.17 + 226.00, received %s = %s + %s.", first.Payment.String(), first.Interest.String(), first.Principal.String())
+	}
+	if last.Balance.String() != "0.00" {
+		t.Errorf("Expected a final balance of 0.00, received '%s'.", last.Balance.String())
+	}
+
+	// The principal repaid must sum to exactly the amount borrowed, and every
+	// payment must be interest plus principal.
+	repaid := parse(t, "0.00")
+	for _, row := range schedule {
+		if err := repaid.Add(&row.Principal); err != nil {
+			t.Fatalf("Expected success, received error '%v'.", err)
+		}
+		sum := row.Interest
+		sum.Add(&row.Principal)
+		if sum.Cmp(&row.Payment) != 0 {
+			t.Errorf("Period %d: %s + %s does not equal %s.", row.Number, row.Interest.String(), row.Principal.String(), row.Payment.String())
+		}
+	}
+	if repaid.Cmp(principal) != 0 {
+		t.Errorf("Expected principal to sum to %s, received %s.", principal.String(), repaid.String())
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/timewasted/go-decimal"
+)
+
+// DefaultMaxIterations is the number of iterations IRR and XIRR perform before
+// giving up with ErrNoConvergence, unless SolverOptions sets another.
+const DefaultMaxIterations = 100
+
+// ErrNoConvergence indicates that the solver used by IRR and XIRR did not
+// find a rate within the allowed number of iterations.
+var ErrNoConvergence = errors.New("finance: rate did not converge")
+
+// ErrNoSignChange indicates that cash flows are all payments or all receipts,
+// so there is no rate at which their value is zero.
+var ErrNoSignChange = errors.New("finance: cash flows do not change sign")
+
+// guardDigits is the number of digits beyond the requested scale that the
+// default tolerance of the solver allows for.
+const guardDigits = 5
+
+// workingScale is the number of digits after the decimal separator that the
+// solver works with, the most that a Decimal can hold.
+const workingScale = 19
+
+// maxToleranceDigits bounds the number of digits after the decimal separator
+// in the default tolerance, so that it can be met at workingScale.
+const maxToleranceDigits = 17
+
+// SolverOptions controls the solver used by IRR and XIRR. A nil
+// *SolverOptions, and any field left at its zero value, selects the default.
+type SolverOptions struct {
+	// Guess is the rate that iteration starts from, 0.1 by default.
+	Guess *decimal.Decimal
+
+	// MaxIterations is the number of iterations performed before giving up
+	// with ErrNoConvergence, DefaultMaxIterations by default.
+	MaxIterations int
+
+	// Tolerance ends iteration once a step changes the discount factor by no
+	// more than it. By default it is 10**-(scale+5), but no less than
+	// 10**-17.
+	Tolerance *decimal.Decimal
+}
+
+// NPV returns the net present value of cashFlows discounted at rate, rounded
+// to scale digits after the decimal separator according to mode. As with the
+// NPV function of spreadsheets, cashFlows[i] occurs at the end of period i+1,
+// so the first cash flow is discounted by one period.
+func NPV(rate *decimal.Decimal, cashFlows []decimal.Decimal, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	if _, err := growth(rate, 0); err != nil {
+		return nil, err
+	}
+	base := new(big.Rat).Add(big.NewRat(1, 1), rate.Rat())
+
+	// Work backwards, so that each cash flow is discounted one more period
+	// than the one after it.
+	npv := new(big.Rat)
+	for i := len(cashFlows) - 1; i >= 0; i-- {
+		if err := check(&cashFlows[i]); err != nil {
+			return nil, err
+		}
+		npv.Add(npv, cashFlows[i].Rat())
+		npv.Quo(npv, base)
+	}
+	return round(npv, scale, mode)
+}
+
+// CashFlow is an amount paid or received on a date.
+type CashFlow struct {
+	Amount decimal.Decimal
+	Date   time.Time
+}
+
+// solver finds the discount factor v at which the cash flows sum(a*v**t) are
+// worth zero, using Newton's method. The discount factor and the discounted
+// cash flows are Decimals rounded to workingScale digits, and their sums are
+// accumulated exactly.
+type solver struct {
+	amounts []decimal.Decimal
+	times   []int
+	max     int
+	tol     *big.Rat
+}
+
+// newSolver returns a solver for amounts occurring at the given times, whose
+// results are to be rounded to scale digits. It returns an error if amounts do
+// not change sign, or if opts are invalid.
+func newSolver(amounts []decimal.Decimal, times []int, scale int, opts *SolverOptions) (*solver, error) {
+	s := &solver{
+		amounts: amounts,
+		times:   times,
+		max:     DefaultMaxIterations,
+	}
+	var positive, negative bool
+	for i := range amounts {
+		if err := check(&amounts[i]); err != nil {
+			return nil, err
+		}
+		sign := amounts[i].Rat().Sign()
+		positive = positive || sign > 0
+		negative = negative || sign < 0
+	}
+	if !positive || !negative {
+		return nil, ErrNoSignChange
+	}
+
+	digits := scale + guardDigits
+	if digits > maxToleranceDigits {
+		digits = maxToleranceDigits
+	}
+	s.tol = new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	if opts != nil {
+		if opts.MaxIterations > 0 {
+			s.max = opts.MaxIterations
+		}
+		if opts.Tolerance != nil {
+			if err := check(opts.Tolerance); err != nil {
+				return nil, err
+			}
+			s.tol = opts.Tolerance.Rat()
+		}
+	}
+	return s, nil
+}
+
+// set returns r as a Decimal, rounded to workingScale digits.
+func (s *solver) set(r *big.Rat) (*decimal.Decimal, error) {
+	d := &decimal.Decimal{}
+	if _, err := d.SetRat(r, workingScale, decimal.ToNearestEven); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// mul returns x*y, rounded to workingScale digits.
+func (s *solver) mul(x, y *decimal.Decimal) (*decimal.Decimal, error) {
+	return s.set(new(big.Rat).Mul(x.Rat(), y.Rat()))
+}
+
+// quo returns x/y, rounded to workingScale digits.
+func (s *solver) quo(x, y *decimal.Decimal) (*decimal.Decimal, error) {
+	z := *x
+	if err := z.Quo(y, workingScale, decimal.ToNearestEven); err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
+
+// pow returns x**n for n >= 0, using repeated squaring.
+func (s *solver) pow(x *decimal.Decimal, n int) (*decimal.Decimal, error) {
+	result, square := decimal.NewFromInt64(1), x
+	var err error
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			if result, err = s.mul(result, square); err != nil {
+				return nil, err
+			}
+		}
+		if n > 1 {
+			if square, err = s.mul(square, square); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// solve runs Newton's method from the discount factor v. Iteration stops once
+// a step changes v by no more than the tolerance.
+func (s *solver) solve(v *decimal.Decimal) (*decimal.Decimal, error) {
+	for i := 0; i < s.max; i++ {
+		// f(v) = sum(a*v**t), and v*f'(v) = sum(t*a*v**t).
+		f, df := new(big.Rat), new(big.Rat)
+		for j := range s.amounts {
+			p, err := s.pow(v, s.times[j])
+			if err != nil {
+				return nil, err
+			}
+			term, err := s.mul(&s.amounts[j], p)
+			if err != nil {
+				return nil, err
+			}
+			f.Add(f, term.Rat())
+			df.Add(df, new(big.Rat).Mul(term.Rat(), big.NewRat(int64(s.times[j]), 1)))
+		}
+		if df.Sign() == 0 {
+			return nil, ErrNoConvergence
+		}
+		fd, err := s.set(f)
+		if err != nil {
+			return nil, err
+		}
+		dfd, err := s.set(df)
+		if err != nil {
+			return nil, err
+		}
+		if dfd.Rat().Sign() == 0 {
+			return nil, ErrNoConvergence
+		}
+
+		// v - f(v)/f'(v) = v - v*f(v)/(v*f'(v))
+		step, err := s.mul(v, fd)
+		if err != nil {
+			return nil, err
+		}
+		if step, err = s.quo(step, dfd); err != nil {
+			return nil, err
+		}
+		next, err := s.set(new(big.Rat).Sub(v.Rat(), step.Rat()))
+		if err != nil {
+			return nil, err
+		}
+		if next.Rat().Sign() <= 0 {
+			// The step overshot past a rate of -100%; approach it instead.
+			if next, err = s.quo(v, decimal.NewFromInt64(2)); err != nil {
+				return nil, err
+			}
+		}
+		if new(big.Rat).Abs(step.Rat()).Cmp(s.tol) <= 0 {
+			return next, nil
+		}
+		v = next
+	}
+	return nil, ErrNoConvergence
+}
+
+// rate returns the rate (1/v)**periods - 1, where v is the discount factor for
+// the given number of periods, rounded to scale digits after the decimal
+// separator according to mode.
+func (s *solver) rate(v *decimal.Decimal, periods int, scale int, mode decimal.RoundingMode) (*decimal.Decimal, error) {
+	inv, err := s.quo(decimal.NewFromInt64(1), v)
+	if err != nil {
+		return nil, err
+	}
+	if inv, err = s.pow(inv, periods); err != nil {
+		return nil, err
+	}
+	return round(new(big.Rat).Sub(inv.Rat(), big.NewRat(1, 1)), scale, mode)
+}
+
+// guessRate returns the guess given by opts as a rational, or 0.1 if there is
+// none.
+func guessRate(opts *SolverOptions) (*big.Rat, error) {
+	if opts == nil || opts.Guess == nil {
+		return big.NewRat(1, 10), nil
+	}
+	if err := check(opts.Guess); err != nil {
+		return nil, err
+	}
+	r := opts.Guess.Rat()
+	if r.Cmp(big.NewRat(-1, 1)) <= 0 {
+		return nil, ErrRate
+	}
+	return r, nil
+}
+
+// IRR returns the internal rate of return of cashFlows, the periodic rate at
+// which their net present value is zero, rounded to scale digits after the
+// decimal separator according to mode. cashFlows[0] occurs at the start of the
+// first period and cashFlows[i] at the end of period i, as with the IRR
+// function of spreadsheets.
+//
+// The rate is found with Newton's method on the discount factor 1/(1+rate),
+// using Decimal division and powers by repeated squaring, starting from the
+// guess given by opts. Cash flows that change sign more than once may have
+// more than one rate, and which is found depends on the guess. Iteration stops
+// once the discount factor changes by no more than the tolerance given by
+// opts, and ErrNoConvergence is returned if that does not happen within the
+// maximum number of iterations. opts may be nil, to use the defaults described
+// for SolverOptions.
+func IRR(cashFlows []decimal.Decimal, scale int, mode decimal.RoundingMode, opts *SolverOptions) (*decimal.Decimal, error) {
+	g, err := guessRate(opts)
+	if err != nil {
+		return nil, err
+	}
+	times := make([]int, len(cashFlows))
+	for i := range times {
+		times[i] = i
+	}
+	s, err := newSolver(cashFlows, times, scale, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	v0, err := s.set(new(big.Rat).Inv(new(big.Rat).Add(big.NewRat(1, 1), g)))
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.solve(v0)
+	if err != nil {
+		return nil, err
+	}
+	return s.rate(v, 1, scale, mode)
+}
+
+// days returns the number of calendar days from the date of t0 to the date of
+// t, ignoring the time of day.
+func days(t0, t time.Time) int {
+	y0, m0, d0 := t0.Date()
+	y, m, d := t.Date()
+	diff := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(time.Date(y0, m0, d0, 0, 0, 0, 0, time.UTC))
+	return int(diff / (24 * time.Hour))
+}
+
+// XIRR returns the annual internal rate of return of cashFlows occurring on
+// irregular dates, rounded to scale digits after the decimal separator
+// according to mode. As with the XIRR function of spreadsheets, each cash flow
+// is discounted by (1+rate)**(days/365), where days is the number of calendar
+// days since the earliest cash flow.
+//
+// The rate is found as described for IRR, with the solver working on the
+// daily discount factor (1+rate)**(-1/365).
+func XIRR(cashFlows []CashFlow, scale int, mode decimal.RoundingMode, opts *SolverOptions) (*decimal.Decimal, error) {
+	g, err := guessRate(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(cashFlows) == 0 {
+		return nil, ErrNoSignChange
+	}
+	start := cashFlows[0].Date
+	for _, cf := range cashFlows[1:] {
+		if cf.Date.Before(start) {
+			start = cf.Date
+		}
+	}
+	amounts := make([]decimal.Decimal, len(cashFlows))
+	times := make([]int, len(cashFlows))
+	for i, cf := range cashFlows {
+		amounts[i] = cf.Amount
+		times[i] = days(start, cf.Date)
+	}
+	s, err := newSolver(amounts, times, scale, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The starting point only needs to be close, so a float64 root is enough.
+	gf, _ := g.Float64()
+	v0, err := s.set(new(big.Rat).SetFloat64(math.Pow(1+gf, -1.0/365)))
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.solve(v0)
+	if err != nil {
+		return nil, err
+	}
+	return s.rate(v, 365, scale, mode)
+}
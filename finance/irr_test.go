@@ -0,0 +1,148 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timewasted/go-decimal"
+)
+
+func parseAll(t *testing.T, values ...string) []decimal.Decimal {
+	result := make([]decimal.Decimal, len(values))
+	for i, s := range values {
+		result[i] = *parse(t, s)
+	}
+	return result
+}
+
+func TestNPV(t *testing.T) {
+	tests := []struct {
+		description, rate string
+		cashFlows         []string
+		shouldFail        bool
+		output            string
+	}{
+		{description: "Spreadsheet example", rate: "0.1", cashFlows: []string{"-10000", "3000", "4200", "6800"}, output: "1188.44"},
+		{description: "Zero rate", rate: "0", cashFlows: []string{"-100", "60", "60"}, output: "20.00"},
+		{description: "No cash flows", rate: "0.1", cashFlows: nil, output: "0.00"},
+		{description: "Rate of -100%", rate: "-1", cashFlows: []string{"100"}, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		result, err := NPV(parse(t, test.rate), parseAll(t, test.cashFlows...), 2, decimal.ToNearestEven)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("%s: Expected success, received error '%v'.", test.description, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("%s: Expected failure, received '%s'.", test.description, result.String())
+			continue
+		}
+		if result.String() != test.output {
+			t.Errorf("%s: Expected '%s', received '%s'.", test.description, test.output, result.String())
+		}
+	}
+}
+
+func TestIRR(t *testing.T) {
+	tests := []struct {
+		description string
+		cashFlows   []string
+		guess       string
+		err         error
+		output      string
+	}{
+		{description: "Five years of returns", cashFlows: []string{"-70000", "12000", "15000", "18000", "21000", "26000"}, output: "0.0866309480"},
+		{description: "Four years of returns", cashFlows: []string{"-100", "39", "59", "55", "20"}, output: "0.2809484212"},
+		{description: "Negative rate", cashFlows: []string{"-70000", "12000", "15000", "18000"}, guess: "-0.1", output: "-0.1821374641"},
+		{description: "Negative rate from the default guess", cashFlows: []string{"-70000", "12000", "15000", "18000"}, output: "-0.1821374641"},
+		{description: "Zero rate", cashFlows: []string{"-100", "50", "50"}, output: "0.0000000000"},
+		{description: "Only payments", cashFlows: []string{"-100", "-50"}, err: ErrNoSignChange},
+		{description: "Guess of -100%", cashFlows: []string{"-100", "110"}, guess: "-1", err: ErrRate},
+	}
+
+	for _, test := range tests {
+		var opts *SolverOptions
+		if test.guess != "" {
+			opts = &SolverOptions{Guess: parse(t, test.guess)}
+		}
+		result, err := IRR(parseAll(t, test.cashFlows...), 10, decimal.ToNearestEven, opts)
+		if err != test.err {
+			t.Errorf("%s: Expected error '%v', received '%v'.", test.description, test.err, err)
+			continue
+		}
+		if err == nil && result.String() != test.output {
+			t.Errorf("%s: Expected '%s', received '%s'.", test.description, test.output, result.String())
+		}
+	}
+}
+
+func TestIRRSolverOptions(t *testing.T) {
+	cashFlows := parseAll(t, "-100", "39", "59", "55", "20")
+
+	if _, err := IRR(cashFlows, 10, decimal.ToNearestEven, &SolverOptions{MaxIterations: 1}); err != ErrNoConvergence {
+		t.Errorf("Expected error '%v', received '%v'.", ErrNoConvergence, err)
+	}
+
+	// A loose tolerance stops iteration early, with a less accurate rate.
+	result, err := IRR(cashFlows, 10, decimal.ToNearestEven, &SolverOptions{Tolerance: parse(t, "0.01")})
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if result.String() == "0.2809484212" {
+		t.Errorf("Expected a less accurate rate, received '%s'.", result.String())
+	}
+	result, err = IRR(cashFlows, 2, decimal.ToNearestEven, &SolverOptions{Tolerance: parse(t, "0.0000001")})
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if result.String() != "0.28" {
+		t.Errorf("Expected '0.28', received '%s'.", result.String())
+	}
+
+	if _, err := IRR(cashFlows, 10, decimal.ToNearestEven, &SolverOptions{Tolerance: &decimal.Decimal{}}); err != decimal.ErrNotValid {
+		t.Errorf("Expected error '%v', received '%v'.", decimal.ErrNotValid, err)
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	cashFlows := []CashFlow{
+		{Amount: *parse(t, "-10000"), Date: date(2008, time.January, 1)},
+		{Amount: *parse(t, "2750"), Date: date(2008, time.March, 1)},
+		{Amount: *parse(t, "4250"), Date: date(2008, time.October, 30)},
+		{Amount: *parse(t, "3250"), Date: date(2009, time.February, 15)},
+		{Amount: *parse(t, "2750"), Date: date(2009, time.April, 1)},
+	}
+
+	result, err := XIRR(cashFlows, 10, decimal.ToNearestEven, nil)
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if result.String() != "0.3733625335" {
+		t.Errorf("Expected '0.3733625335', received '%s'.", result.String())
+	}
+
+	// The order of cash flows, and the time of day, do not matter.
+	cashFlows[0], cashFlows[4] = cashFlows[4], cashFlows[0]
+	cashFlows[2].Date = cashFlows[2].Date.Add(23 * time.Hour)
+	result, err = XIRR(cashFlows, 10, decimal.ToNearestEven, nil)
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if result.String() != "0.3733625335" {
+		t.Errorf("Expected '0.3733625335', received '%s'.", result.String())
+	}
+
+	if _, err := XIRR(nil, 10, decimal.ToNearestEven, nil); err != ErrNoSignChange {
+		t.Errorf("Expected error '%v', received '%v'.", ErrNoSignChange, err)
+	}
+}
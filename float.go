@@ -0,0 +1,171 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NewFromFloat64 returns a new Decimal holding the shortest decimal value that
+// converts back to f exactly, as printed by strconv.FormatFloat(f, 'g', -1,
+// 64). For example, 0.1 is converted to 0.1.
+//
+// ErrNotFinite is returned if f is NaN or an infinity, and ErrRange is
+// returned if the value can not be represented by a Decimal.
+func NewFromFloat64(f float64) (*Decimal, error) {
+	const fnName = "NewFromFloat64"
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, &NumError{fnName, strconv.FormatFloat(f, 'g', -1, 64), ErrNotFinite}
+	}
+	return parseScientific(fnName, strconv.FormatFloat(f, 'e', -1, 64))
+}
+
+// NewFromFloat64Special is like NewFromFloat64, except that NaN and infinities
+// are converted to the equivalent special values instead of being rejected.
+func NewFromFloat64Special(f float64) (*Decimal, error) {
+	switch {
+	case math.IsNaN(f):
+		return NaN(), nil
+	case math.IsInf(f, 0):
+		return Inf(int(math.Copysign(1, f))), nil
+	}
+	return parseScientific("NewFromFloat64Special", strconv.FormatFloat(f, 'e', -1, 64))
+}
+
+// NewFromFloat64Exact returns a new Decimal holding the exact value of the
+// binary floating point number f. For example, 0.5 is converted to 0.5, and
+// 2**-10 is converted to 0.0009765625.
+//
+// Most binary fractions need far more digits than a Decimal can hold (0.1 is
+// 0.1000000000000000055511151231257827... and needs 55 digits after the
+// decimal separator), in which case ErrRange is returned. FormatFloat64Exact
+// gives the exact value of any f. ErrNotFinite is returned if f is NaN or an
+// infinity.
+func NewFromFloat64Exact(f float64) (*Decimal, error) {
+	const fnName = "NewFromFloat64Exact"
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, &NumError{fnName, strconv.FormatFloat(f, 'g', -1, 64), ErrNotFinite}
+	}
+	c, scale := float64Coefficient(f)
+	decimal := &Decimal{}
+	if !decimal.setCoefficient(c, scale) {
+		return nil, rangeError(fnName, strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	return decimal, nil
+}
+
+// FormatFloat64Exact returns the exact value of the binary floating point
+// number f, formatted as with String. Unlike NewFromFloat64Exact, it is not
+// limited to the values that a Decimal can hold, so 0.1 is formatted as
+// 0.1000000000000000055511151231257827021181583404541015625. ErrNotFinite is
+// returned if f is NaN or an infinity.
+func FormatFloat64Exact(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", &NumError{"FormatFloat64Exact", strconv.FormatFloat(f, 'g', -1, 64), ErrNotFinite}
+	}
+	c, scale := float64Coefficient(f)
+	sign := ""
+	if c.Sign() < 0 {
+		sign = "-"
+		c.Neg(c)
+	}
+	if scale == 0 {
+		return sign + c.String() + string(DecimalSeparator) + "0", nil
+	}
+	q, r := new(big.Int).QuoRem(c, pow10(scale), new(big.Int))
+	fraction := r.String()
+	return sign + q.String() + string(DecimalSeparator) + strings.Repeat("0", scale-len(fraction)) + fraction, nil
+}
+
+// float64Coefficient returns the coefficient and scale of the exact value of
+// the finite f, without trailing zeros after the decimal separator.
+func float64Coefficient(f float64) (*big.Int, int) {
+	// f == mantissa * 2**exp, with mantissa an integer and exp minimized.
+	frac, exp := math.Frexp(f)
+	mantissa := int64(math.Ldexp(frac, 53))
+	exp -= 53
+	if mantissa == 0 {
+		exp = 0
+	}
+	for mantissa != 0 && mantissa%2 == 0 {
+		mantissa /= 2
+		exp++
+	}
+
+	c := big.NewInt(mantissa)
+	if exp >= 0 {
+		return c.Lsh(c, uint(exp)), 0
+	}
+	// mantissa * 2**exp == mantissa * 5**-exp * 10**exp.
+	return c.Mul(c, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-exp)), nil)), -exp
+}
+
+// specialFloat64 returns the float64 equivalent of the special value d, and
+// whether or not the conversion was exact. An infinity converts exactly, while
+// NaN does not.
+func (d *Decimal) specialFloat64() (float64, bool) {
+	if d.form == infinite {
+		if d.Negative {
+			return math.Inf(-1), true
+		}
+		return math.Inf(1), true
+	}
+	return math.NaN(), false
+}
+
+// Float64 returns the float64 value nearest to d, and whether or not the
+// conversion was exact. Halfway cases are rounded to even. If d is not Valid,
+// (0, false) is returned. NaN and infinities are converted to the equivalent
+// float64 values, with NaN never being exact.
+func (d *Decimal) Float64() (f float64, exact bool) {
+	if !d.Valid {
+		return 0, false
+	}
+	if d.form != finite {
+		return d.specialFloat64()
+	}
+	return d.rat().Float64()
+}
+
+// Float64Round returns the value of d as a float64, rounded according to mode,
+// and whether or not the conversion was exact. Exact is treated as
+// ToNearestEven. Values that are not finite or Valid are converted as with
+// Float64.
+func (d *Decimal) Float64Round(mode RoundingMode) (f float64, exact bool) {
+	if !d.Valid {
+		return 0, false
+	}
+	if d.form != finite {
+		return d.specialFloat64()
+	}
+	q := d.rat()
+
+	// big.Float rounds subnormal float64 values to nearest even whatever its
+	// mode, so they are rounded here instead, as multiples of the smallest
+	// subnormal value.
+	if new(big.Rat).Abs(q).Cmp(minNormalFloat64) < 0 {
+		if mode == Exact {
+			mode = ToNearestEven
+		}
+		n := new(big.Int).Lsh(q.Num(), -minFloat64Exp)
+		c, exact := quoRound(n, q.Denom(), mode)
+		f := math.Ldexp(float64(c.Int64()), minFloat64Exp)
+		return math.Copysign(f, float64(q.Sign())), exact
+	}
+	bf := new(big.Float).SetPrec(53).SetMode(bigRoundingMode(mode)).SetRat(q)
+	f, acc := bf.Float64()
+	return f, acc == big.Exact && bf.Acc() == big.Exact
+}
+
+// minFloat64Exp is the binary exponent of the smallest subnormal float64, and
+// minNormalFloat64 is the smallest normal float64.
+const minFloat64Exp = -1074
+
+var minNormalFloat64 = new(big.Rat).SetFloat64(math.Ldexp(1, -1022))
@@ -0,0 +1,213 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewFromFloat64(t *testing.T) {
+	tests := map[float64]string{
+		0:                                   "0.0",
+		math.Copysign(0, -1):                "0.0",
+		1:                                   "1.0",
+		-1:                                  "-1.0",
+		0.1:                                 "0.1",
+		-0.1:                                "-0.1",
+		123.45:                              "123.45",
+		1e-10:                               "0.0000000001",
+		1e19:                                "10000000000000000000.0",
+		18446744073709549568:                "18446744073709550000.0",
+		0.30000000000000004:                 "0.30000000000000004",
+		math.SmallestNonzeroFloat64 * 1e300: "0.000000000000000000000004940656458412466",
+	}
+
+	for input, output := range tests {
+		d, err := NewFromFloat64(input)
+		if err != nil {
+			t.Errorf("%g: Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.String() != output {
+			t.Errorf("%g: Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+
+	for _, input := range []float64{1e20, -1e20, math.MaxFloat64} {
+		if _, err := NewFromFloat64(input); err == nil || err.(*NumError).Err != ErrRange {
+			t.Errorf("%g: Expected ErrRange, received '%v'.", input, err)
+		}
+	}
+	for _, input := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewFromFloat64(input); err == nil || err.(*NumError).Err != ErrNotFinite {
+			t.Errorf("%g: Expected ErrNotFinite, received '%v'.", input, err)
+		}
+	}
+}
+
+func TestNewFromFloat64Special(t *testing.T) {
+	tests := map[float64]string{
+		math.NaN():   "NaN",
+		math.Inf(1):  "Infinity",
+		math.Inf(-1): "-Infinity",
+		0.1:          "0.1",
+		-2.5:         "-2.5",
+	}
+
+	for input, output := range tests {
+		d, err := NewFromFloat64Special(input)
+		if err != nil {
+			t.Errorf("%g: Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.String() != output {
+			t.Errorf("%g: Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+	if _, err := NewFromFloat64Special(1e300); err == nil || err.(*NumError).Err != ErrRange {
+		t.Errorf("1e300: Expected ErrRange, received '%v'.", err)
+	}
+}
+
+func TestNewFromFloat64Exact(t *testing.T) {
+	tests := map[float64]string{
+		0:                    "0.0",
+		1:                    "1.0",
+		-1:                   "-1.0",
+		0.5:                  "0.5",
+		-0.375:               "-0.375",
+		1.0 / 1024:           "0.0009765625",
+		123.125:              "123.125",
+		1 << 62:              "4611686018427387904.0",
+		18446744073709549568: "18446744073709549568.0",
+		math.Ldexp(1, -20):   "0.00000095367431640625",
+	}
+
+	for input, output := range tests {
+		d, err := NewFromFloat64Exact(input)
+		if err != nil {
+			t.Errorf("%g: Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.String() != output {
+			t.Errorf("%g: Expected '%s', received '%s'.", input, output, d.String())
+		}
+	}
+
+	for _, input := range []float64{0.1, 1e20, math.Ldexp(1, -70)} {
+		if _, err := NewFromFloat64Exact(input); err == nil || err.(*NumError).Err != ErrRange {
+			t.Errorf("%g: Expected ErrRange, received '%v'.", input, err)
+		}
+	}
+	if _, err := NewFromFloat64Exact(math.NaN()); err == nil || err.(*NumError).Err != ErrNotFinite {
+		t.Errorf("NaN: Expected ErrNotFinite, received '%v'.", err)
+	}
+}
+
+func TestFormatFloat64Exact(t *testing.T) {
+	tests := map[float64]string{
+		0:                  "0.0",
+		1:                  "1.0",
+		-0.375:             "-0.375",
+		0.1:                "0.1000000000000000055511151231257827021181583404541015625",
+		123.456:            "123.4560000000000030695446184836328029632568359375",
+		1e20:               "100000000000000000000.0",
+		math.Ldexp(1, -70): "0.0000000000000000000008470329472543003390683225006796419620513916015625",
+	}
+
+	for input, output := range tests {
+		s, err := FormatFloat64Exact(input)
+		if err != nil || s != output {
+			t.Errorf("%g: Expected '%s', received '%s' (error '%v').", input, output, s, err)
+		}
+	}
+	if s, err := FormatFloat64Exact(math.Ldexp(1, -1074)); err != nil || len(s) != 1076 {
+		t.Errorf("2**-1074: Expected 1076 characters, received %d (error '%v').", len(s), err)
+	}
+	if _, err := FormatFloat64Exact(math.Inf(-1)); err == nil || err.(*NumError).Err != ErrNotFinite {
+		t.Errorf("-Inf: Expected ErrNotFinite, received '%v'.", err)
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	type float64Test struct {
+		input  string
+		output float64
+		exact  bool
+	}
+
+	tests := []float64Test{
+		{input: "0", output: 0, exact: true},
+		{input: "0.5", output: 0.5, exact: true},
+		{input: "-123.125", output: -123.125, exact: true},
+		{input: "0.1", output: 0.1},
+		{input: "-0.1", output: -0.1},
+		{input: "18446744073709551615", output: 18446744073709551615},
+		{input: "9007199254740993", output: 9007199254740992},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		f, exact := d.Float64()
+		if f != test.output || exact != test.exact {
+			t.Errorf("'%s': Expected %g (exact %t), received %g (exact %t).", test.input, test.output, test.exact, f, exact)
+		}
+	}
+
+	if f, exact := (&Decimal{}).Float64(); f != 0 || exact {
+		t.Errorf("Expected (0, false) for an invalid Decimal, received (%g, %t).", f, exact)
+	}
+}
+
+func TestFloat64Round(t *testing.T) {
+	type float64RoundTest struct {
+		input  string
+		mode   RoundingMode
+		output float64
+		exact  bool
+	}
+
+	subnormal := "0." + strings.Repeat("0", 323) + "6422853395936205"
+	tests := []float64RoundTest{
+		{input: "0.5", mode: ToZero, output: 0.5, exact: true},
+		{input: "0.1", mode: ToNearestEven, output: 0.1},
+		{input: "0.1", mode: ToZero, output: math.Nextafter(0.1, 0)},
+		{input: "0.1", mode: ToPositiveInf, output: 0.1},
+		{input: "-0.1", mode: ToNegativeInf, output: -0.1},
+		{input: "-0.1", mode: ToPositiveInf, output: math.Nextafter(-0.1, 0)},
+		{input: "9007199254740993", mode: ToNearestEven, output: 9007199254740992},
+		{input: "9007199254740993", mode: ToNearestAway, output: 9007199254740994},
+		{input: "9007199254740993", mode: AwayFromZero, output: 9007199254740994},
+		{input: "9007199254740993", mode: Exact, output: 9007199254740992},
+
+		// 1.3 times the smallest subnormal value.
+		{input: subnormal, mode: ToNearestEven, output: math.SmallestNonzeroFloat64},
+		{input: subnormal, mode: ToZero, output: math.SmallestNonzeroFloat64},
+		{input: subnormal, mode: ToPositiveInf, output: 2 * math.SmallestNonzeroFloat64},
+		{input: subnormal, mode: AwayFromZero, output: 2 * math.SmallestNonzeroFloat64},
+		{input: "-" + subnormal, mode: ToNegativeInf, output: -2 * math.SmallestNonzeroFloat64},
+		{input: "-" + subnormal, mode: ToPositiveInf, output: -math.SmallestNonzeroFloat64},
+		{input: "0." + strings.Repeat("0", 400) + "1", mode: ToPositiveInf, output: math.SmallestNonzeroFloat64},
+		{input: "0." + strings.Repeat("0", 400) + "1", mode: ToNearestEven, output: 0},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		f, exact := d.Float64Round(test.mode)
+		if f != test.output || exact != test.exact {
+			t.Errorf("'%s' (%v): Expected %g (exact %t), received %g (exact %t).", test.input, test.mode, test.output, test.exact, f, exact)
+		}
+	}
+}
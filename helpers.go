@@ -4,7 +4,10 @@
 
 package decimal
 
-import "math"
+import (
+	"math"
+	"math/big"
+)
 
 func printedLength(n uint64) int {
 	if n == 0 {
@@ -16,9 +19,234 @@ func printedLength(n uint64) int {
 	return int(math.Floor(math.Log10(float64(n))) + 1)
 }
 
-func simplifyNumber(n uint64) (uint64, int) {
-	for n >= 10 && n%10 == 0 {
+// simplifyNumber removes trailing zeros from the denominator n, which has the
+// given number of digits. Leading zeros are significant, so the returned digit
+// count is reduced only by the number of zeros removed.
+func simplifyNumber(n uint64, digits int) (uint64, int) {
+	if n == 0 {
+		return 0, 1
+	}
+	for n%10 == 0 {
 		n /= 10
+		digits--
+	}
+	return n, digits
+}
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// pow10 returns 10**n as a *big.Int. n must not be negative.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// coefficient returns the signed, unscaled value of d. The value of d is
+// coefficient * 10**-denominatorDigits.
+func (d *Decimal) coefficient() *big.Int {
+	c := new(big.Int).SetUint64(d.numerator)
+	c.Mul(c, pow10(d.denominatorDigits))
+	c.Add(c, new(big.Int).SetUint64(d.denominator))
+	if d.Negative {
+		c.Neg(c)
+	}
+	return c
+}
+
+// setCoefficient sets d to c * 10**-scale. False is returned, and d is left
+// unchanged, if the value can not be represented by a Decimal, or scale is out
+// of bounds.
+func (d *Decimal) setCoefficient(c *big.Int, scale int) bool {
+	if !validScale(scale) {
+		return false
+	}
+	if scale < 0 {
+		c = new(big.Int).Mul(c, pow10(-scale))
+		scale = 0
+	}
+	numerator, denominator := new(big.Int).QuoRem(new(big.Int).Abs(c), pow10(scale), new(big.Int))
+	if !numerator.IsUint64() || !denominator.IsUint64() {
+		return false
+	}
+
+	d.Valid = true
+	d.Negative = c.Sign() < 0
+	d.form = finite
+	d.numerator = numerator.Uint64()
+	d.denominator = denominator.Uint64()
+	d.denominatorDigits = scale
+	return true
+}
+
+// rescale returns the coefficient c, which has the given scale, adjusted to
+// have newScale digits after the decimal separator. Rounding is performed
+// according to mode, and whether or not the result is exact is returned.
+func rescale(c *big.Int, scale, newScale int, mode RoundingMode) (*big.Int, bool) {
+	if newScale >= scale {
+		return new(big.Int).Mul(c, pow10(newScale-scale)), true
+	}
+	return quoRound(c, pow10(scale-newScale), mode)
+}
+
+// twosComplement returns the minimal big-endian two's-complement encoding of
+// c. Zero is encoded as a single zero byte.
+func twosComplement(c *big.Int) []byte {
+	if c.Sign() >= 0 {
+		b := c.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+
+	// For negative values, encode -c-1 and then invert every bit.
+	m := new(big.Int).Neg(c)
+	m.Sub(m, bigOne)
+	b := m.Bytes()
+	if len(b) == 0 || b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	for i := range b {
+		b[i] = ^b[i]
+	}
+	return b
+}
+
+// fromTwosComplement decodes the big-endian two's-complement value b.
+func fromTwosComplement(b []byte) *big.Int {
+	if len(b) == 0 || b[0]&0x80 == 0 {
+		return new(big.Int).SetBytes(b)
+	}
+	inverted := make([]byte, len(b))
+	for i := range b {
+		inverted[i] = ^b[i]
+	}
+	c := new(big.Int).SetBytes(inverted)
+	c.Add(c, bigOne)
+	return c.Neg(c)
+}
+
+// maxExponent bounds the scales and exponents accepted from input, so that
+// hostile input can not force the computation of enormous powers of ten.
+const maxExponent = 10000
+
+// validScale reports whether scale is within the bounds set by maxExponent.
+func validScale(scale int) bool {
+	return -maxExponent <= scale && scale <= maxExponent
+}
+
+// parseScientific converts the string s, which uses '.' as the decimal
+// separator and may have an exponent, into a Decimal. The format is:
+//
+// [Sign] Significand [Exponent]
+//
+// Sign is a negative (-) or positive (+) sign
+// Significand is Digits ['.'] [Digits] or [Digits] '.' Digits
+// Exponent is 'e' or 'E', followed by an optional Sign and Digits
+func parseScientific(fnName, s string) (*Decimal, error) {
+	i := 0
+	negative := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		negative = s[i] == '-'
+		i++
+	}
+
+	var digits []byte
+	sawDigits, sawSeparator := false, false
+	scale := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		if '0' <= c && c <= '9' {
+			digits = append(digits, c)
+			sawDigits = true
+			if sawSeparator {
+				scale++
+			}
+			continue
+		}
+		if c == '.' && !sawSeparator {
+			sawSeparator = true
+			continue
+		}
+		break
+	}
+	if !sawDigits {
+		return nil, syntaxError(fnName, s)
+	}
+
+	if i < len(s) {
+		if s[i] != 'e' && s[i] != 'E' {
+			return nil, syntaxError(fnName, s)
+		}
+		i++
+		expNegative := false
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			expNegative = s[i] == '-'
+			i++
+		}
+		if i == len(s) {
+			return nil, syntaxError(fnName, s)
+		}
+		exp := 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c < '0' || c > '9' {
+				return nil, syntaxError(fnName, s)
+			}
+			exp = exp*10 + int(c-'0')
+			if exp > maxExponent {
+				return nil, rangeError(fnName, s)
+			}
+		}
+		if expNegative {
+			exp = -exp
+		}
+		scale -= exp
+	}
+
+	c, _ := new(big.Int).SetString(string(digits), 10)
+	if negative {
+		c.Neg(c)
+	}
+	decimal := &Decimal{}
+	if !decimal.setCoefficient(c, scale) {
+		return nil, rangeError(fnName, s)
+	}
+	return decimal, nil
+}
+
+// trimZeros removes trailing fractional zeros from the coefficient c, which
+// has the given scale. The adjusted coefficient and scale are returned.
+func trimZeros(c *big.Int, scale int) (*big.Int, int) {
+	c = new(big.Int).Set(c)
+	q, r := new(big.Int), new(big.Int)
+	for scale > 0 {
+		q.QuoRem(c, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		c, q = q, c
+		scale--
+	}
+	return c, scale
+}
+
+// rat returns the value of d as a *big.Rat.
+func (d *Decimal) rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.coefficient(), pow10(d.denominatorDigits))
+}
+
+// cmpFraction compares the fractional parts of d1 and d2, which may have
+// differing numbers of digits, and returns -1, 0 or +1.
+func cmpFraction(d1, d2 *Decimal) int {
+	f1 := new(big.Int).SetUint64(d1.denominator)
+	f2 := new(big.Int).SetUint64(d2.denominator)
+	if d1.denominatorDigits > d2.denominatorDigits {
+		f2.Mul(f2, pow10(d1.denominatorDigits-d2.denominatorDigits))
+	} else if d2.denominatorDigits > d1.denominatorDigits {
+		f1.Mul(f1, pow10(d2.denominatorDigits-d1.denominatorDigits))
 	}
-	return n, printedLength(n)
+	return f1.Cmp(f2)
 }
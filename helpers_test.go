@@ -4,7 +4,12 @@
 
 package decimal
 
-import "testing"
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
 
 func TestSimplifyNumber(t *testing.T) {
 	// NOTE: This also tests printedLength().
@@ -109,7 +114,7 @@ func TestSimplifyNumber(t *testing.T) {
 	}
 
 	for value, result := range tests {
-		n, d := simplifyNumber(value)
+		n, d := simplifyNumber(value, printedLength(value))
 		if result.number != n {
 			t.Errorf("Expected %d to return %d, received %d.", value, result.number, n)
 		}
@@ -118,3 +123,94 @@ func TestSimplifyNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestSimplifyNumberLeadingZeros(t *testing.T) {
+	// 0.0500 has a denominator of 500 with 4 digits.
+	n, d := simplifyNumber(500, 4)
+	if n != 5 || d != 2 {
+		t.Errorf("Expected (5, 2), received (%d, %d).", n, d)
+	}
+	n, d = simplifyNumber(1, 20)
+	if n != 1 || d != 20 {
+		t.Errorf("Expected (1, 20), received (%d, %d).", n, d)
+	}
+}
+
+func TestTwosComplement(t *testing.T) {
+	tests := map[int64]string{
+		0:      "00",
+		1:      "01",
+		127:    "7f",
+		128:    "0080",
+		255:    "00ff",
+		256:    "0100",
+		-1:     "ff",
+		-128:   "80",
+		-129:   "ff7f",
+		-256:   "ff00",
+		-32768: "8000",
+		-32769: "ff7fff",
+	}
+
+	for value, encoded := range tests {
+		b := twosComplement(big.NewInt(value))
+		if hex.EncodeToString(b) != encoded {
+			t.Errorf("Expected %d to encode as '%s', received '%s'.", value, encoded, hex.EncodeToString(b))
+		}
+		if decoded := fromTwosComplement(b); decoded.Int64() != value {
+			t.Errorf("Expected '%s' to decode as %d, received %d.", encoded, value, decoded.Int64())
+		}
+	}
+}
+
+func TestSetCoefficient(t *testing.T) {
+	type coefficientTest struct {
+		coefficient string
+		scale       int
+		shouldFail  bool
+		output      string
+	}
+
+	tests := []coefficientTest{
+		{coefficient: "12345", scale: 2, output: "123.45"},
+		{coefficient: "-12345", scale: 2, output: "-123.45"},
+		{coefficient: "12345", scale: 0, output: "12345.0"},
+		{coefficient: "12345", scale: -2, output: "1234500.0"},
+		{coefficient: "100", scale: 2, output: "1.00"},
+		{coefficient: "5", scale: 3, output: "0.005"},
+		{coefficient: "0", scale: 2, output: "0.00"},
+		{coefficient: "18446744073709551615", scale: 0, output: "18446744073709551615.0"},
+		{coefficient: "18446744073709551616", scale: 0, shouldFail: true},
+		{coefficient: "18446744073709551615", scale: 20, output: "0.18446744073709551615"},
+		{coefficient: "18446744073709551616", scale: 20, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		c, _ := new(big.Int).SetString(test.coefficient, 10)
+		d := &Decimal{}
+		if !d.setCoefficient(c, test.scale) {
+			if !test.shouldFail {
+				t.Errorf("%se-%d: expected success.", test.coefficient, test.scale)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("%se-%d: expected failure.", test.coefficient, test.scale)
+			continue
+		}
+		if d.String() != test.output {
+			t.Errorf("%se-%d: expected '%s', received '%s'.", test.coefficient, test.scale, test.output, d.String())
+		}
+		if test.scale < 0 {
+			c.Mul(c, pow10(-test.scale))
+		}
+		if d.coefficient().Cmp(c) != 0 {
+			t.Errorf("%se-%d: coefficient did not round trip, received %s.", test.coefficient, test.scale, d.coefficient())
+		}
+	}
+}
+
+// stripSpaces removes the spaces used to make encoded test values readable.
+func stripSpaces(s string) string {
+	return strings.Replace(s, " ", "", -1)
+}
@@ -0,0 +1,135 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Int64 returns the integer part of d as an int64. The fractional part is
+// discarded (truncated towards zero). ErrRange is returned if the integer part
+// is less than minSignedInt64 or greater than maxSignedInt64.
+func (d *Decimal) Int64() (int64, error) {
+	if err := d.checkFinite(); err != nil {
+		return 0, err
+	}
+	if d.Negative {
+		if d.numerator > -minSignedInt64 {
+			return 0, rangeError("Int64", d.String())
+		}
+		// Negate numerator-1 so that minSignedInt64 does not overflow.
+		return -int64(d.numerator-1) - 1, nil
+	}
+	if d.numerator > maxSignedInt64 {
+		return 0, rangeError("Int64", d.String())
+	}
+	return int64(d.numerator), nil
+}
+
+// Uint64 returns the integer part of d as a uint64. The fractional part is
+// discarded (truncated towards zero), so values between -1 and 0 return 0.
+// ErrRange is returned for any other negative value.
+func (d *Decimal) Uint64() (uint64, error) {
+	if err := d.checkFinite(); err != nil {
+		return 0, err
+	}
+	if d.Negative && d.numerator != 0 {
+		return 0, rangeError("Uint64", d.String())
+	}
+	return d.numerator, nil
+}
+
+// IntPart returns a new Decimal holding the integer part of d. The fractional
+// part is discarded (truncated towards zero).
+func (d *Decimal) IntPart() (*Decimal, error) {
+	if err := d.checkFinite(); err != nil {
+		return nil, err
+	}
+	return &Decimal{Valid: true, Negative: d.Negative && d.numerator != 0, numerator: d.numerator}, nil
+}
+
+// Trunc returns a new Decimal holding d truncated towards zero, so that
+// Trunc(-1.2) is -1. It is the same as IntPart.
+func (d *Decimal) Trunc() (*Decimal, error) {
+	return d.IntPart()
+}
+
+// Floor returns a new Decimal holding the greatest integer less than or equal
+// to d, so that Floor(-1.2) is -2. ErrRange is returned if the result is less
+// than -maxUnsignedInt64.
+func (d *Decimal) Floor() (*Decimal, error) {
+	return d.roundToInteger("Floor", d.Negative)
+}
+
+// Ceil returns a new Decimal holding the least integer greater than or equal
+// to d, so that Ceil(-1.2) is -1. ErrRange is returned if the result is
+// greater than maxUnsignedInt64.
+func (d *Decimal) Ceil() (*Decimal, error) {
+	return d.roundToInteger("Ceil", !d.Negative)
+}
+
+// roundToInteger implements Floor and Ceil, rounding d away from zero if it has
+// a fractional part and away is true, and towards zero otherwise.
+func (d *Decimal) roundToInteger(fnName string, away bool) (*Decimal, error) {
+	ip, err := d.IntPart()
+	if err != nil || !away || d.denominator == 0 {
+		return ip, err
+	}
+	if ip.numerator == maxUnsignedInt64 {
+		return nil, rangeError(fnName, d.String())
+	}
+	ip.numerator++
+	ip.Negative = d.Negative
+	return ip, nil
+}
+
+// Frac returns a new Decimal holding the fractional part of d, with the same
+// sign and scale as d, so that Frac(-1.25) is -0.25. d equals the sum of
+// Trunc(d) and Frac(d).
+func (d *Decimal) Frac() (*Decimal, error) {
+	if err := d.checkFinite(); err != nil {
+		return nil, err
+	}
+	return &Decimal{Valid: true, Negative: d.Negative && d.denominator != 0, denominator: d.denominator, denominatorDigits: d.denominatorDigits}, nil
+}
+
+// ToMinorUnits returns d as an integer number of minor units, where exp is the
+// number of minor unit digits (for example 2 for USD, where 1.23 is 123
+// cents, or 0 for JPY). If d has more than exp fractional digits, it is
+// rounded according to mode. If mode is Exact, ErrInexact is returned
+// instead. ErrRange is returned if the result does not fit in an int64, or if
+// exp is out of range.
+func (d *Decimal) ToMinorUnits(exp int, mode RoundingMode) (int64, error) {
+	const fnName = "ToMinorUnits"
+
+	if err := d.checkFinite(); err != nil {
+		return 0, err
+	}
+	if !validScale(exp) {
+		return 0, rangeError(fnName, d.String())
+	}
+	units, exact := rescale(d.coefficient(), d.denominatorDigits, exp, mode)
+	if !exact && mode == Exact {
+		return 0, inexactError(fnName, d.String())
+	}
+	if !units.IsInt64() {
+		return 0, rangeError(fnName, d.String())
+	}
+	return units.Int64(), nil
+}
+
+// FromMinorUnits returns a new Decimal with the value of units minor units,
+// where exp is the number of minor unit digits. For example, 123 units with an
+// exp of 2 returns 1.23. The result always has exp digits after the decimal
+// separator. ErrRange is returned if exp is negative and the result
+// overflows, or if exp is less than -10000 or more than 10000.
+func FromMinorUnits(units int64, exp int) (*Decimal, error) {
+	decimal := &Decimal{}
+	if !decimal.setCoefficient(big.NewInt(units), exp) {
+		return nil, rangeError("FromMinorUnits", strconv.FormatInt(units, 10)+"e"+strconv.Itoa(-exp))
+	}
+	return decimal, nil
+}
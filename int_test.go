@@ -0,0 +1,269 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestInt64(t *testing.T) {
+	type int64Test struct {
+		input      string
+		shouldFail bool
+		output     int64
+	}
+
+	tests := []int64Test{
+		{input: "0", output: 0},
+		{input: "123.99", output: 123},
+		{input: "-123.99", output: -123},
+		{input: "-0.5", output: 0},
+		{input: "9223372036854775807.9", output: 9223372036854775807},
+		{input: "-9223372036854775808.9", output: -9223372036854775808},
+		{input: "9223372036854775808", shouldFail: true},
+		{input: "-9223372036854775809", shouldFail: true},
+		{input: "18446744073709551615", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		i, err := d.Int64()
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s': Expected failure, received %d.", test.input, i)
+			continue
+		}
+		if i != test.output {
+			t.Errorf("'%s': Expected %d, received %d.", test.input, test.output, i)
+		}
+	}
+
+	if _, err := (&Decimal{}).Int64(); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid for an invalid Decimal, received '%v'.", err)
+	}
+}
+
+func TestUint64(t *testing.T) {
+	type uint64Test struct {
+		input      string
+		shouldFail bool
+		output     uint64
+	}
+
+	tests := []uint64Test{
+		{input: "0", output: 0},
+		{input: "123.99", output: 123},
+		{input: "-0.99", output: 0},
+		{input: "18446744073709551615.99", output: 18446744073709551615},
+		{input: "-1", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		u, err := d.Uint64()
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s': Expected failure, received %d.", test.input, u)
+			continue
+		}
+		if u != test.output {
+			t.Errorf("'%s': Expected %d, received %d.", test.input, test.output, u)
+		}
+	}
+}
+
+func TestIntPart(t *testing.T) {
+	tests := map[string]string{
+		"0.0":                     "0.0",
+		"123.45":                  "123.0",
+		"-123.45":                 "-123.0",
+		"-0.45":                   "0.0",
+		"18446744073709551615.99": "18446744073709551615.0",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		ip, err := d.IntPart()
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if ip.String() != output {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, output, ip.String())
+		}
+		if ip.Negative && ip.numerator == 0 {
+			t.Errorf("'%s': Expected zero to not be negative.", input)
+		}
+	}
+}
+
+func TestFloorCeilTruncFrac(t *testing.T) {
+	tests := []struct {
+		input                    string
+		floor, ceil, trunc, frac string
+	}{
+		{input: "1.2", floor: "1.0", ceil: "2.0", trunc: "1.0", frac: "0.2"},
+		{input: "-1.2", floor: "-2.0", ceil: "-1.0", trunc: "-1.0", frac: "-0.2"},
+		{input: "-0.45", floor: "-1.0", ceil: "0.0", trunc: "0.0", frac: "-0.45"},
+		{input: "0.001", floor: "0.0", ceil: "1.0", trunc: "0.0", frac: "0.001"},
+		{input: "-3.00", floor: "-3.0", ceil: "-3.0", trunc: "-3.0", frac: "0.00"},
+		{input: "0", floor: "0.0", ceil: "0.0", trunc: "0.0", frac: "0.0"},
+		{input: "18446744073709551615", floor: "18446744073709551615.0", ceil: "18446744073709551615.0", trunc: "18446744073709551615.0", frac: "0.0"},
+		{input: "18446744073709551614.5", floor: "18446744073709551614.0", ceil: "18446744073709551615.0", trunc: "18446744073709551614.0", frac: "0.5"},
+		{input: "-18446744073709551614.5", floor: "-18446744073709551615.0", ceil: "-18446744073709551614.0", trunc: "-18446744073709551614.0", frac: "-0.5"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		results := map[string]func() (*Decimal, error){"Floor": d.Floor, "Ceil": d.Ceil, "Trunc": d.Trunc, "Frac": d.Frac}
+		expected := map[string]string{"Floor": test.floor, "Ceil": test.ceil, "Trunc": test.trunc, "Frac": test.frac}
+		for name, fn := range results {
+			r, err := fn()
+			if err != nil || r.String() != expected[name] {
+				t.Errorf("%s('%s'): Expected '%s', received '%v' (error '%v').", name, test.input, expected[name], r, err)
+				continue
+			}
+			if r.Negative && r.IsZero() {
+				t.Errorf("%s('%s'): Expected zero to not be negative.", name, test.input)
+			}
+		}
+	}
+
+	// The carry out of the integer part overflows.
+	for input, fn := range map[string]string{"18446744073709551615.5": "Ceil", "-18446744073709551615.5": "Floor"} {
+		d, _ := ParseDecimal(input)
+		f := d.Ceil
+		if fn == "Floor" {
+			f = d.Floor
+		}
+		if _, err := f(); err == nil || err.(*NumError).Err != ErrRange {
+			t.Errorf("%s('%s'): Expected ErrRange, received '%v'.", fn, input, err)
+		}
+	}
+
+	for _, d := range []*Decimal{{}, NaN(), Inf(1)} {
+		for name, fn := range map[string]func() (*Decimal, error){"Floor": d.Floor, "Ceil": d.Ceil, "Trunc": d.Trunc, "Frac": d.Frac} {
+			if _, err := fn(); err != d.checkFinite() {
+				t.Errorf("%s('%s'): Expected error '%v', received '%v'.", name, d.String(), d.checkFinite(), err)
+			}
+		}
+	}
+}
+
+func TestToMinorUnits(t *testing.T) {
+	type minorUnitsTest struct {
+		input      string
+		exp        int
+		mode       RoundingMode
+		shouldFail bool
+		output     int64
+	}
+
+	tests := []minorUnitsTest{
+		{input: "1.23", exp: 2, mode: Exact, output: 123},
+		{input: "-1.23", exp: 2, mode: Exact, output: -123},
+		{input: "1.2", exp: 2, mode: Exact, output: 120},
+		{input: "1.230", exp: 2, mode: Exact, output: 123},
+		{input: "1500", exp: 0, mode: Exact, output: 1500},
+		{input: "1.5", exp: 3, mode: Exact, output: 1500},
+		{input: "1.235", exp: 2, mode: ToNearestEven, output: 124},
+		{input: "1.245", exp: 2, mode: ToNearestEven, output: 124},
+		{input: "1.245", exp: 2, mode: ToNearestAway, output: 125},
+		{input: "-1.245", exp: 2, mode: ToNearestAway, output: -125},
+		{input: "1.5", exp: 0, mode: ToZero, output: 1},
+		{input: "1.235", exp: 2, mode: Exact, shouldFail: true},
+		{input: "0.5", exp: 0, mode: Exact, shouldFail: true},
+		{input: "92233720368547758.07", exp: 2, mode: Exact, output: 9223372036854775807},
+		{input: "-92233720368547758.08", exp: 2, mode: Exact, output: -9223372036854775808},
+		{input: "92233720368547758.08", exp: 2, mode: Exact, shouldFail: true},
+		{input: "92233720368547758.075", exp: 2, mode: ToNearestEven, shouldFail: true},
+		{input: "92233720368547758.075", exp: 2, mode: ToZero, output: 9223372036854775807},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		units, err := d.ToMinorUnits(test.exp, test.mode)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s' (exp %d, %v): Expected success, received error '%v'.", test.input, test.exp, test.mode, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s' (exp %d, %v): Expected failure, received %d.", test.input, test.exp, test.mode, units)
+			continue
+		}
+		if units != test.output {
+			t.Errorf("'%s' (exp %d, %v): Expected %d, received %d.", test.input, test.exp, test.mode, test.output, units)
+		}
+	}
+
+	for _, exp := range []int{1 << 30, -1 << 30} {
+		if _, err := NewFromInt64(1).ToMinorUnits(exp, ToNearestEven); err == nil || err.(*NumError).Err != ErrRange {
+			t.Errorf("Exp %d: Expected ErrRange, received '%v'.", exp, err)
+		}
+	}
+}
+
+func TestFromMinorUnits(t *testing.T) {
+	type minorUnitsTest struct {
+		units  int64
+		exp    int
+		output string
+	}
+
+	tests := []minorUnitsTest{
+		{units: 123, exp: 2, output: "1.23"},
+		{units: -123, exp: 2, output: "-1.23"},
+		{units: 100, exp: 2, output: "1.00"},
+		{units: 1500, exp: 0, output: "1500.0"},
+		{units: 1, exp: 3, output: "0.001"},
+		{units: -9223372036854775808, exp: 2, output: "-92233720368547758.08"},
+	}
+
+	for _, test := range tests {
+		d, err := FromMinorUnits(test.units, test.exp)
+		if err != nil {
+			t.Errorf("(%d, %d): Expected success, received error '%v'.", test.units, test.exp, err)
+			continue
+		}
+		if d.String() != test.output {
+			t.Errorf("(%d, %d): Expected '%s', received '%s'.", test.units, test.exp, test.output, d.String())
+		}
+	}
+
+	for _, exp := range []int{-2, 1 << 30} {
+		if _, err := FromMinorUnits(9223372036854775807, exp); err == nil || err.(*NumError).Err != ErrRange {
+			t.Errorf("Exp %d: Expected ErrRange, received '%v'.", exp, err)
+		}
+	}
+}
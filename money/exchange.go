@@ -0,0 +1,145 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import (
+	"errors"
+	"time"
+
+	"github.com/timewasted/go-decimal"
+)
+
+// ErrInvalidRate indicates that an exchange rate is not positive.
+var ErrInvalidRate = errors.New("money: exchange rate must be positive")
+
+// ErrNoPivot indicates that two exchange rates do not share exactly one
+// currency to triangulate through.
+var ErrNoPivot = errors.New("money: exchange rates have no common pivot currency")
+
+// ExchangeRate is the price of one unit of the Base currency, expressed in the
+// Quote currency. For example, a Base of EUR, a Quote of USD and a Rate of
+// 1.0842 means that 1 EUR buys 1.0842 USD.
+type ExchangeRate struct {
+	Base, Quote Currency
+	Rate        decimal.Decimal
+	Time        time.Time // when the rate was observed
+}
+
+// NewExchangeRate returns a new ExchangeRate between the currencies with the
+// alphabetic codes base and quote. ErrInvalidRate is returned if rate is not
+// positive.
+func NewExchangeRate(base, quote string, rate *decimal.Decimal, t time.Time) (*ExchangeRate, error) {
+	b, err := LookupCurrency(base)
+	if err != nil {
+		return nil, err
+	}
+	q, err := LookupCurrency(quote)
+	if err != nil {
+		return nil, err
+	}
+	if !validRate(rate) {
+		return nil, ErrInvalidRate
+	}
+	return &ExchangeRate{Base: b, Quote: q, Rate: *rate, Time: t}, nil
+}
+
+// validRate reports whether rate is a valid, positive value.
+func validRate(rate *decimal.Decimal) bool {
+	return rate.IsFinite() && rate.IsPositive()
+}
+
+// Convert returns the value of m, which must be in the Base currency, in the
+// Quote currency. The amount is multiplied by the rate exactly, and the
+// product is then rounded once, to the minor unit of the Quote currency,
+// according to mode. A *CurrencyMismatchError is returned if m is not in the
+// Base currency.
+func (r *ExchangeRate) Convert(m *Money, mode decimal.RoundingMode) (*Money, error) {
+	if m.Currency != r.Base {
+		return nil, &CurrencyMismatchError{"Convert", r.Base, m.Currency}
+	}
+	if !validRate(&r.Rate) {
+		return nil, ErrInvalidRate
+	}
+
+	product := m.Amount
+	if err := product.Mul(&r.Rate); err != nil {
+		return nil, err
+	}
+	converted := &Money{Currency: r.Quote}
+	if err := roundTo(&converted.Amount, &product, r.Quote.Exponent, mode); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// Invert returns the reverse exchange rate, from the Quote currency to the
+// Base currency. The inverted rate is rounded to scale digits after the
+// decimal separator according to mode.
+func (r *ExchangeRate) Invert(scale int, mode decimal.RoundingMode) (*ExchangeRate, error) {
+	if !validRate(&r.Rate) {
+		return nil, ErrInvalidRate
+	}
+	inverted := &ExchangeRate{Base: r.Quote, Quote: r.Base, Rate: *decimal.NewFromInt64(1), Time: r.Time}
+	if err := inverted.Rate.Quo(&r.Rate, scale, mode); err != nil {
+		return nil, err
+	}
+	return inverted, nil
+}
+
+// Cross triangulates an exchange rate through the one currency that a and b
+// have in common (the pivot). For example, EUR/USD and USD/JPY give EUR/JPY,
+// and USD/EUR and USD/JPY give EUR/JPY. Rates are inverted as needed, and the
+// cross rate is computed exactly and then rounded once to scale digits after
+// the decimal separator according to mode. The earlier of the two times is
+// used as the time of the cross rate.
+//
+// ErrNoPivot is returned if a and b do not share exactly one currency.
+func Cross(a, b *ExchangeRate, scale int, mode decimal.RoundingMode) (*ExchangeRate, error) {
+	if !validRate(&a.Rate) || !validRate(&b.Rate) {
+		return nil, ErrInvalidRate
+	}
+
+	// The cross rate is the product of a leg from the base to the pivot and a
+	// leg from the pivot to the quote. Each leg is either a rate or the
+	// reciprocal of one, so collect the rates into a numerator and a
+	// denominator and divide once at the end.
+	numerator, denominator := decimal.NewFromInt64(1), decimal.NewFromInt64(1)
+	cross := &ExchangeRate{Time: a.Time}
+	if b.Time.Before(a.Time) {
+		cross.Time = b.Time
+	}
+
+	var pivot Currency
+	switch {
+	case a.Quote == b.Base || a.Quote == b.Quote:
+		pivot, cross.Base = a.Quote, a.Base
+		numerator.Mul(&a.Rate)
+	case a.Base == b.Base || a.Base == b.Quote:
+		pivot, cross.Base = a.Base, a.Quote
+		denominator.Mul(&a.Rate)
+	default:
+		return nil, ErrNoPivot
+	}
+	if b.Base == pivot {
+		cross.Quote = b.Quote
+		if err := numerator.Mul(&b.Rate); err != nil {
+			return nil, err
+		}
+	} else {
+		cross.Quote = b.Base
+		if err := denominator.Mul(&b.Rate); err != nil {
+			return nil, err
+		}
+	}
+	if cross.Base == cross.Quote {
+		return nil, ErrNoPivot
+	}
+
+	if err := numerator.Quo(denominator, scale, mode); err != nil {
+		return nil, err
+	}
+	cross.Rate = *numerator
+	return cross, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timewasted/go-decimal"
+)
+
+func mustRate(t *testing.T, base, quote, rate string, when time.Time) *ExchangeRate {
+	d, err := decimal.ParseDecimal(rate)
+	if err != nil {
+		t.Fatalf("'%s': Expected success, received error '%v'.", rate, err)
+	}
+	r, err := NewExchangeRate(base, quote, d, when)
+	if err != nil {
+		t.Fatalf("%s/%s: Expected success, received error '%v'.", base, quote, err)
+	}
+	return r
+}
+
+func TestNewExchangeRate(t *testing.T) {
+	for _, rate := range []string{"0", "-1.5"} {
+		d, _ := decimal.ParseDecimal(rate)
+		if _, err := NewExchangeRate("EUR", "USD", d, time.Time{}); err != ErrInvalidRate {
+			t.Errorf("'%s': Expected ErrInvalidRate, received '%v'.", rate, err)
+		}
+	}
+	d, _ := decimal.ParseDecimal("1.5")
+	if _, err := NewExchangeRate("EUR", "ABC", d, time.Time{}); err != ErrUnknownCurrency {
+		t.Errorf("Expected ErrUnknownCurrency, received '%v'.", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	type convertTest struct {
+		base, quote, rate string
+		amount            string
+		mode              decimal.RoundingMode
+		output            string
+	}
+
+	tests := []convertTest{
+		{base: "EUR", quote: "USD", rate: "1.084231", amount: "100.00", mode: decimal.ToNearestEven, output: "108.42 USD"},
+		{base: "EUR", quote: "USD", rate: "1.084231", amount: "-100.00", mode: decimal.ToNearestEven, output: "-108.42 USD"},
+		{base: "USD", quote: "JPY", rate: "151.2345678901", amount: "10.00", mode: decimal.ToNearestEven, output: "1512 JPY"},
+		{base: "USD", quote: "JPY", rate: "151.2345678901", amount: "10.00", mode: decimal.ToPositiveInf, output: "1513 JPY"},
+		{base: "USD", quote: "BHD", rate: "0.376", amount: "12.34", mode: decimal.ToNearestEven, output: "4.640 BHD"},
+		{base: "GBP", quote: "EUR", rate: "1.165", amount: "0.10", mode: decimal.ToNearestEven, output: "0.12 EUR"},
+		{base: "GBP", quote: "EUR", rate: "1.165", amount: "0.10", mode: decimal.ToZero, output: "0.11 EUR"},
+	}
+
+	for _, test := range tests {
+		r := mustRate(t, test.base, test.quote, test.rate, time.Time{})
+		m, _ := Parse(test.amount, test.base)
+		converted, err := r.Convert(m, test.mode)
+		if err != nil {
+			t.Errorf("%s %s at %s: Expected success, received error '%v'.", test.amount, test.base, test.rate, err)
+			continue
+		}
+		if converted.String() != test.output {
+			t.Errorf("%s %s at %s: Expected '%s', received '%s'.", test.amount, test.base, test.rate, test.output, converted.String())
+		}
+	}
+
+	r := mustRate(t, "EUR", "USD", "1.08", time.Time{})
+	m, _ := Parse("1.00", "USD")
+	if _, err := r.Convert(m, decimal.ToNearestEven); err == nil {
+		t.Errorf("Expected a *CurrencyMismatchError converting USD with a EUR/USD rate.")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	when := time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC)
+	r := mustRate(t, "EUR", "USD", "1.084231", when)
+	inverted, err := r.Invert(10, decimal.ToNearestEven)
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if inverted.Base.Code != "USD" || inverted.Quote.Code != "EUR" || !inverted.Time.Equal(when) {
+		t.Errorf("Unexpected inverted rate %+v.", inverted)
+	}
+	if inverted.Rate.String() != "0.9223126806" {
+		t.Errorf("Expected '0.9223126806', received '%s'.", inverted.Rate.String())
+	}
+}
+
+func TestCross(t *testing.T) {
+	early := time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC)
+	late := early.Add(time.Minute)
+
+	type crossTest struct {
+		description       string
+		a, b              *ExchangeRate
+		base, quote, rate string
+	}
+
+	tests := []crossTest{
+		{
+			description: "Quote of a is base of b",
+			a:           mustRate(t, "EUR", "USD", "1.08", early),
+			b:           mustRate(t, "USD", "JPY", "150.5", late),
+			base:        "EUR",
+			quote:       "JPY",
+			rate:        "162.540000",
+		},
+		{
+			description: "Shared base",
+			a:           mustRate(t, "USD", "EUR", "0.92", late),
+			b:           mustRate(t, "USD", "JPY", "150.5", early),
+			base:        "EUR",
+			quote:       "JPY",
+			rate:        "163.586957",
+		},
+		{
+			description: "Shared quote",
+			a:           mustRate(t, "EUR", "USD", "1.08", early),
+			b:           mustRate(t, "GBP", "USD", "1.27", late),
+			base:        "EUR",
+			quote:       "GBP",
+			rate:        "0.850394",
+		},
+		{
+			description: "Base of a is quote of b",
+			a:           mustRate(t, "USD", "CHF", "0.88", early),
+			b:           mustRate(t, "EUR", "USD", "1.08", early),
+			base:        "CHF",
+			quote:       "EUR",
+			rate:        "1.052189",
+		},
+	}
+
+	for _, test := range tests {
+		cross, err := Cross(test.a, test.b, 6, decimal.ToNearestEven)
+		if err != nil {
+			t.Errorf("%s: Expected success, received error '%v'.", test.description, err)
+			continue
+		}
+		if cross.Base.Code != test.base || cross.Quote.Code != test.quote || cross.Rate.String() != test.rate {
+			t.Errorf("%s: Expected %s/%s %s, received %s/%s %s.", test.description, test.base, test.quote, test.rate, cross.Base, cross.Quote, cross.Rate.String())
+		}
+		if !cross.Time.Equal(early) {
+			t.Errorf("%s: Expected the earlier time, received %v.", test.description, cross.Time)
+		}
+	}
+
+	a := mustRate(t, "EUR", "USD", "1.08", early)
+	if _, err := Cross(a, mustRate(t, "GBP", "JPY", "190", early), 6, decimal.ToNearestEven); err != ErrNoPivot {
+		t.Errorf("Expected ErrNoPivot, received '%v'.", err)
+	}
+	if _, err := Cross(a, mustRate(t, "USD", "EUR", "0.92", early), 6, decimal.ToNearestEven); err != ErrNoPivot {
+		t.Errorf("Expected ErrNoPivot, received '%v'.", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package money pairs Decimal amounts with ISO 4217 currencies, and refuses
+// to mix amounts of different currencies.
+package money
+
+import (
+	"github.com/timewasted/go-decimal"
+)
+
+// CurrencyMismatchError records an operation that was attempted on amounts of
+// different currencies.
+type CurrencyMismatchError struct {
+	Op   string   // the failing operation
+	A, B Currency // the currencies of the operands
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return "money." + e.Op + ": currency mismatch: " + e.A.Code + " and " + e.B.Code
+}
+
+// Money is an amount of a currency.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency Currency
+}
+
+// New returns a new Money holding amount of the currency with the alphabetic
+// code code. ErrUnknownCurrency is returned if there is no such currency.
+func New(amount *decimal.Decimal, code string) (*Money, error) {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+	return &Money{Amount: *amount, Currency: c}, nil
+}
+
+// Parse returns a new Money holding the amount in the string s, as parsed by
+// decimal.ParseDecimal, of the currency with the alphabetic code code.
+func Parse(s, code string) (*Money, error) {
+	amount, err := decimal.ParseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	return New(amount, code)
+}
+
+// Add sets m to the sum of m+n. A *CurrencyMismatchError is returned if m and
+// n are of different currencies. m is unchanged on error.
+func (m *Money) Add(n *Money) error {
+	if m.Currency != n.Currency {
+		return &CurrencyMismatchError{"Add", m.Currency, n.Currency}
+	}
+	return m.Amount.Add(&n.Amount)
+}
+
+// Sub sets m to the result of m-n. A *CurrencyMismatchError is returned if m
+// and n are of different currencies. m is unchanged on error.
+func (m *Money) Sub(n *Money) error {
+	if m.Currency != n.Currency {
+		return &CurrencyMismatchError{"Sub", m.Currency, n.Currency}
+	}
+	return m.Amount.Sub(&n.Amount)
+}
+
+// Cmp compares the amounts of m and n, as decimal.Decimal.Cmp does. A
+// *CurrencyMismatchError is returned if m and n are of different currencies.
+func (m *Money) Cmp(n *Money) (int, error) {
+	if m.Currency != n.Currency {
+		return 0, &CurrencyMismatchError{"Cmp", m.Currency, n.Currency}
+	}
+	return m.Amount.Cmp(&n.Amount), nil
+}
+
+// Round returns a new Money with the amount of m rounded to the minor unit of
+// its currency according to mode.
+func (m *Money) Round(mode decimal.RoundingMode) (*Money, error) {
+	rounded := &Money{Currency: m.Currency}
+	if err := roundTo(&rounded.Amount, &m.Amount, m.Currency.Exponent, mode); err != nil {
+		return nil, err
+	}
+	return rounded, nil
+}
+
+// roundTo sets z to the value of x, rounded to scale digits after the decimal
+// separator according to mode.
+func roundTo(z, x *decimal.Decimal, scale int, mode decimal.RoundingMode) error {
+	if !x.Valid {
+		return decimal.ErrNotValid
+	}
+	if !x.IsFinite() {
+		return decimal.ErrNotFinite
+	}
+	_, err := z.SetRat(x.Rat(), scale, mode)
+	return err
+}
+
+// String returns the amount of m, formatted with the number of fraction
+// digits of its currency, followed by the currency code. For example,
+// "1234.50 USD" or "1235 JPY". Amounts with more fraction digits than the
+// currency allows are rounded to nearest even.
+func (m *Money) String() string {
+	rounded, err := m.Round(decimal.ToNearestEven)
+	if err != nil {
+		return m.Amount.String() + " " + m.Currency.Code
+	}
+	if m.Currency.Exponent == 0 {
+		i, _ := rounded.Amount.BigInt()
+		return i.String() + " " + m.Currency.Code
+	}
+	return rounded.Amount.String() + " " + m.Currency.Code
+}
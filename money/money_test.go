@@ -0,0 +1,131 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package money
+
+import "testing"
+
+func TestLookupCurrency(t *testing.T) {
+	type currencyTest struct {
+		code     string
+		numeric  int
+		exponent int
+	}
+
+	tests := []currencyTest{
+		{code: "USD", numeric: 840, exponent: 2},
+		{code: "EUR", numeric: 978, exponent: 2},
+		{code: "JPY", numeric: 392, exponent: 0},
+		{code: "BHD", numeric: 48, exponent: 3},
+		{code: "CLF", numeric: 990, exponent: 4},
+	}
+
+	for _, test := range tests {
+		c, err := LookupCurrency(test.code)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.code, err)
+			continue
+		}
+		if c.Code != test.code || c.Numeric != test.numeric || c.Exponent != test.exponent {
+			t.Errorf("'%s': Expected (%d, %d), received %+v.", test.code, test.numeric, test.exponent, c)
+		}
+		if n, err := LookupNumeric(test.numeric); err != nil || n != c {
+			t.Errorf("%d: Expected %+v, received %+v (error '%v').", test.numeric, c, n, err)
+		}
+	}
+
+	for _, code := range []string{"", "usd", "XXX", "ABC"} {
+		if _, err := LookupCurrency(code); err != ErrUnknownCurrency {
+			t.Errorf("'%s': Expected ErrUnknownCurrency, received '%v'.", code, err)
+		}
+	}
+	if _, err := LookupNumeric(0); err != ErrUnknownCurrency {
+		t.Errorf("0: Expected ErrUnknownCurrency, received '%v'.", err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	m, _ := Parse("10.25", "USD")
+	n, _ := Parse("0.75", "USD")
+	if err := m.Add(n); err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if m.String() != "11.00 USD" {
+		t.Errorf("Expected '11.00 USD', received '%s'.", m.String())
+	}
+
+	e, _ := Parse("1.00", "EUR")
+	err := m.Add(e)
+	mismatch, ok := err.(*CurrencyMismatchError)
+	if !ok {
+		t.Fatalf("Expected a *CurrencyMismatchError, received '%v'.", err)
+	}
+	if mismatch.Op != "Add" || mismatch.A.Code != "USD" || mismatch.B.Code != "EUR" {
+		t.Errorf("Unexpected error contents %+v.", mismatch)
+	}
+	if m.String() != "11.00 USD" {
+		t.Errorf("Expected the amount to be unchanged, received '%s'.", m.String())
+	}
+}
+
+func TestSub(t *testing.T) {
+	m, _ := Parse("10.25", "EUR")
+	n, _ := Parse("0.75", "EUR")
+	if err := m.Sub(n); err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if m.String() != "9.50 EUR" {
+		t.Errorf("Expected '9.50 EUR', received '%s'.", m.String())
+	}
+
+	j, _ := Parse("1", "JPY")
+	if _, ok := m.Sub(j).(*CurrencyMismatchError); !ok {
+		t.Errorf("Expected a *CurrencyMismatchError.")
+	}
+}
+
+func TestCmp(t *testing.T) {
+	m, _ := Parse("10.25", "GBP")
+	n, _ := Parse("10.3", "GBP")
+	if r, err := m.Cmp(n); err != nil || r != -1 {
+		t.Errorf("Expected -1, received %d (error '%v').", r, err)
+	}
+
+	c, _ := Parse("10.25", "CHF")
+	if _, err := m.Cmp(c); err == nil {
+		t.Errorf("Expected a *CurrencyMismatchError.")
+	}
+}
+
+func TestString(t *testing.T) {
+	type stringTest struct {
+		amount, code, output string
+	}
+
+	tests := []stringTest{
+		{amount: "1234.5", code: "USD", output: "1234.50 USD"},
+		{amount: "-1234.5", code: "USD", output: "-1234.50 USD"},
+		{amount: "1234.5", code: "JPY", output: "1234 JPY"},
+		{amount: "1235.5", code: "JPY", output: "1236 JPY"},
+		{amount: "1.2345", code: "BHD", output: "1.234 BHD"},
+		{amount: "1", code: "CLF", output: "1.0000 CLF"},
+		{amount: "0.005", code: "EUR", output: "0.00 EUR"},
+		{amount: "0.015", code: "EUR", output: "0.02 EUR"},
+	}
+
+	for _, test := range tests {
+		m, err := Parse(test.amount, test.code)
+		if err != nil {
+			t.Errorf("'%s %s': Expected success, received error '%v'.", test.amount, test.code, err)
+			continue
+		}
+		if m.String() != test.output {
+			t.Errorf("'%s %s': Expected '%s', received '%s'.", test.amount, test.code, test.output, m.String())
+		}
+	}
+
+	if _, err := Parse("1.00", "ABC"); err != ErrUnknownCurrency {
+		t.Errorf("Expected ErrUnknownCurrency, received '%v'.", err)
+	}
+}
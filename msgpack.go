@@ -0,0 +1,110 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// MsgpackExtType is the MessagePack extension type used for Decimals. It is
+// the type to register with the MessagePack codec in use.
+const MsgpackExtType int8 = 1
+
+// MessagePack format codes.
+const (
+	msgpackNil      = 0xc0
+	msgpackExt8     = 0xc7
+	msgpackExt16    = 0xc8
+	msgpackExt32    = 0xc9
+	msgpackFixExt1  = 0xd4
+	msgpackFixExt16 = 0xd8
+)
+
+// MarshalMsgpack implements the Marshaler interface used by MessagePack
+// libraries such as github.com/vmihailenco/msgpack. The Decimal is encoded as
+// an extension of type MsgpackExtType. The payload is the scale as a
+// zig-zag varint (see encoding/binary.PutVarint), followed by the unscaled
+// value as a big-endian two's-complement integer. A Decimal that is not Valid
+// is encoded as nil. ErrNotFinite is returned for NaN and infinities.
+func (d *Decimal) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	if d.form != finite {
+		return nil, &NumError{"MarshalMsgpack", d.String(), ErrNotFinite}
+	}
+
+	payload := binary.AppendVarint(nil, int64(d.denominatorDigits))
+	payload = append(payload, twosComplement(d.coefficient())...)
+
+	var b []byte
+	switch n := len(payload); {
+	case n == 1 || n == 2 || n == 4 || n == 8 || n == 16:
+		// fixext 1 through fixext 16 are consecutive format codes.
+		code := byte(msgpackFixExt1)
+		for size := 1; size < n; size <<= 1 {
+			code++
+		}
+		b = append(b, code)
+	case n <= 0xff:
+		b = append(b, msgpackExt8, byte(n))
+	case n <= 0xffff:
+		b = append(b, msgpackExt16, byte(n>>8), byte(n))
+	default:
+		b = append(b, msgpackExt32)
+		b = binary.BigEndian.AppendUint32(b, uint32(n))
+	}
+	b = append(b, byte(MsgpackExtType))
+	return append(b, payload...), nil
+}
+
+// UnmarshalMsgpack implements the Unmarshaler interface used by MessagePack
+// libraries such as github.com/vmihailenco/msgpack. data must hold a single
+// extension of type MsgpackExtType, as produced by MarshalMsgpack, or nil.
+// Nil sets Valid to false.
+func (d *Decimal) UnmarshalMsgpack(data []byte) error {
+	const fnName = "UnmarshalMsgpack"
+
+	if len(data) == 1 && data[0] == msgpackNil {
+		*d = Decimal{}
+		return nil
+	}
+	if len(data) == 0 {
+		return syntaxError(fnName, "")
+	}
+
+	var n, header int
+	switch code := data[0]; {
+	case code >= msgpackFixExt1 && code <= msgpackFixExt16:
+		n, header = 1<<(code-msgpackFixExt1), 1
+	case code == msgpackExt8 && len(data) >= 2:
+		n, header = int(data[1]), 2
+	case code == msgpackExt16 && len(data) >= 3:
+		n, header = int(binary.BigEndian.Uint16(data[1:])), 3
+	case code == msgpackExt32 && len(data) >= 5:
+		n, header = int(binary.BigEndian.Uint32(data[1:])), 5
+	default:
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+	if len(data) != header+1+n || int8(data[header]) != MsgpackExtType {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+
+	payload := data[header+1:]
+	scale, size := binary.Varint(payload)
+	if size <= 0 || size == len(payload) {
+		return syntaxError(fnName, hex.EncodeToString(data))
+	}
+	if scale < -maxExponent || scale > maxExponent {
+		return rangeError(fnName, hex.EncodeToString(data))
+	}
+	decimal := Decimal{}
+	if !decimal.setCoefficient(fromTwosComplement(payload[size:]), int(scale)) {
+		return rangeError(fnName, hex.EncodeToString(data))
+	}
+	*d = decimal
+	return nil
+}
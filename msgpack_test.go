@@ -0,0 +1,77 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestMarshalMsgpack(t *testing.T) {
+	tests := map[string]string{
+		"0.0":     "d5 01 02 00",
+		"1.5":     "d5 01 02 0f",
+		"-1.5":    "d5 01 02 f1",
+		"123.45":  "c7 03 01 04 3039",
+		"-123.45": "c7 03 01 04 cfc7",
+		"12345":   "c7 03 01 00 3039",
+		"18446744073709551615.18446744073709551615": "c7 12 01 28 056bc75e2d630ffffb9438a1d29cefffff",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		b, err := d.MarshalMsgpack()
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if hex.EncodeToString(b) != stripSpaces(output) {
+			t.Errorf("'%s': Expected '%s', received '%s'.", input, stripSpaces(output), hex.EncodeToString(b))
+		}
+
+		decoded := &Decimal{}
+		if err := decoded.UnmarshalMsgpack(b); err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if decoded.String() != d.String() {
+			t.Errorf("'%s': Expected '%s' after round trip, received '%s'.", input, d.String(), decoded.String())
+		}
+	}
+
+	b, err := (&Decimal{}).MarshalMsgpack()
+	if err != nil || hex.EncodeToString(b) != "c0" {
+		t.Errorf("Expected an invalid Decimal to encode as nil, received '%x' (error '%v').", b, err)
+	}
+}
+
+func TestUnmarshalMsgpack(t *testing.T) {
+	d, _ := ParseDecimal("1.5")
+	if err := d.UnmarshalMsgpack([]byte{0xc0}); err != nil || d.Valid {
+		t.Errorf("Expected nil to decode as an invalid Decimal, received '%s' (error '%v').", d.String(), err)
+	}
+
+	failures := []string{
+		"",
+		"d5 02 02 0f",
+		"d5 01 02",
+		"d6 01 02 0f",
+		"d4 01 02",
+		"c7 01 01 04",
+		"c7 03 01 04 3039 00",
+		"c7 0a 01 00 010000000000000000",
+		"c0 00",
+	}
+	for _, input := range failures {
+		b, _ := hex.DecodeString(stripSpaces(input))
+		if err := (&Decimal{}).UnmarshalMsgpack(b); err == nil {
+			t.Errorf("'%s': Expected failure.", input)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// bigHundred converts percentages, which are given in percent (a pct of 20
+// means 20%), into fractions.
+var bigHundred = big.NewRat(100, 1)
+
+// roundedRat returns a new Decimal holding r, rounded to scale digits after the
+// decimal separator according to mode.
+func roundedRat(fnName string, r *big.Rat, scale int, mode RoundingMode) (*Decimal, error) {
+	d := &Decimal{}
+	if _, err := d.setRat(fnName, r, scale, mode); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ApplyPercent returns d increased by pct percent, rounded to scale digits
+// after the decimal separator according to mode. A positive pct is a markup,
+// and a negative pct is a discount. For example, applying -15 to 80.00 returns
+// 68.00. Stacked discounts are applied by calling ApplyPercent repeatedly.
+func ApplyPercent(d, pct *Decimal, scale int, mode RoundingMode) (*Decimal, error) {
+	if err := allFinite(d, pct); err != nil {
+		return nil, err
+	}
+	factor := new(big.Rat).Add(bigHundred, pct.rat())
+	r := new(big.Rat).Mul(d.rat(), factor)
+	return roundedRat("ApplyPercent", r.Quo(r, bigHundred), scale, mode)
+}
+
+// PercentOf returns pct percent of d, rounded to scale digits after the
+// decimal separator according to mode. For example, 15 percent of 80.00 is
+// 12.00.
+func PercentOf(d, pct *Decimal, scale int, mode RoundingMode) (*Decimal, error) {
+	if err := allFinite(d, pct); err != nil {
+		return nil, err
+	}
+	r := new(big.Rat).Mul(d.rat(), pct.rat())
+	return roundedRat("PercentOf", r.Quo(r, bigHundred), scale, mode)
+}
+
+// AddTaxExclusive computes the tax due on the tax-exclusive amount net at rate
+// percent, and the resulting gross amount. The tax is rounded to scale digits
+// after the decimal separator according to mode, and gross is net plus the
+// rounded tax, so that gross-tax is always net when net has no more than scale
+// digits after the decimal separator.
+func AddTaxExclusive(net, rate *Decimal, scale int, mode RoundingMode) (gross, tax *Decimal, err error) {
+	const fnName = "AddTaxExclusive"
+
+	if err := allFinite(net, rate); err != nil {
+		return nil, nil, err
+	}
+	r := new(big.Rat).Mul(net.rat(), rate.rat())
+	if tax, err = roundedRat(fnName, r.Quo(r, bigHundred), scale, mode); err != nil {
+		return nil, nil, err
+	}
+	if gross, err = roundedRat(fnName, r.Add(net.rat(), tax.rat()), scale, mode); err != nil {
+		return nil, nil, err
+	}
+	return gross, tax, nil
+}
+
+// ExtractTaxInclusive splits the tax-inclusive amount gross into its net
+// amount and the tax at rate percent. The tax is gross*rate/(100+rate),
+// rounded to scale digits after the decimal separator according to mode, and
+// net is gross minus the rounded tax, so that net+tax is always gross when
+// gross has no more than scale digits after the decimal separator.
+func ExtractTaxInclusive(gross, rate *Decimal, scale int, mode RoundingMode) (net, tax *Decimal, err error) {
+	const fnName = "ExtractTaxInclusive"
+
+	if err := allFinite(gross, rate); err != nil {
+		return nil, nil, err
+	}
+	divisor := new(big.Rat).Add(bigHundred, rate.rat())
+	if divisor.Sign() == 0 {
+		return nil, nil, &NumError{fnName, rate.String(), ErrDivisionByZero}
+	}
+	r := new(big.Rat).Mul(gross.rat(), rate.rat())
+	if tax, err = roundedRat(fnName, r.Quo(r, divisor), scale, mode); err != nil {
+		return nil, nil, err
+	}
+	if net, err = roundedRat(fnName, r.Sub(gross.rat(), tax.rat()), scale, mode); err != nil {
+		return nil, nil, err
+	}
+	return net, tax, nil
+}
+
+// ChangePercent returns the percentage change from the value from to the
+// value to, rounded to scale digits after the decimal separator according to
+// mode. The change is (to-from)/|from|*100, so a move from 80 to 100 is 25,
+// and a move from -10 to -5 is 50. ErrDivisionByZero is returned if from is
+// zero.
+func ChangePercent(from, to *Decimal, scale int, mode RoundingMode) (*Decimal, error) {
+	const fnName = "ChangePercent"
+
+	if err := allFinite(from, to); err != nil {
+		return nil, err
+	}
+	base := from.rat()
+	if base.Sign() == 0 {
+		return nil, &NumError{fnName, from.String() + " -> " + to.String(), ErrDivisionByZero}
+	}
+	r := to.rat()
+	r.Sub(r, base)
+	r.Mul(r, bigHundred)
+	return roundedRat(fnName, r.Quo(r, base.Abs(base)), scale, mode)
+}
@@ -0,0 +1,147 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+type percentTest struct {
+	description, input, pct string
+	mode                    RoundingMode
+	shouldFail              bool
+	output, output2         string
+}
+
+func TestApplyPercent(t *testing.T) {
+	tests := []percentTest{
+		{description: "Markup", input: "80.00", pct: "25", output: "100.00"},
+		{description: "Discount", input: "80.00", pct: "-15", output: "68.00"},
+		{description: "Discount, rounded half even", input: "9.99", pct: "-12.5", mode: ToNearestEven, output: "8.74"},
+		{description: "Discount, rounded half up", input: "0.10", pct: "-25", mode: ToNearestAway, output: "0.08"},
+		{description: "Discount, rounded half even at a tie", input: "0.10", pct: "-25", mode: ToNearestEven, output: "0.08"},
+		{description: "Discount, rounded down", input: "0.10", pct: "-25", mode: ToZero, output: "0.07"},
+		{description: "Full discount", input: "19.99", pct: "-100", output: "0.00"},
+		{description: "Inexact", input: "9.99", pct: "-12.5", mode: Exact, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, _ := ParseDecimal(test.input)
+		pct, _ := ParseDecimal(test.pct)
+		result, err := ApplyPercent(d, pct, 2, test.mode)
+		checkPercentResult(t, test, result, nil, err)
+	}
+
+	// Stacked discounts compound, rounding after each step.
+	d, _ := ParseDecimal("100.00")
+	for _, p := range []string{"-10", "-10"} {
+		pct, _ := ParseDecimal(p)
+		d, _ = ApplyPercent(d, pct, 2, ToNearestAway)
+	}
+	if d.String() != "81.00" {
+		t.Errorf("Stacked discounts: expected '81.00', received '%s'.", d.String())
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	tests := []percentTest{
+		{description: "Whole percentage", input: "80.00", pct: "15", output: "12.00"},
+		{description: "Fractional percentage", input: "1234.56", pct: "2.5", mode: ToNearestEven, output: "30.86"},
+		{description: "Tie, rounded half up", input: "0.50", pct: "5", mode: ToNearestAway, output: "0.03"},
+		{description: "Tie, rounded half even", input: "0.50", pct: "5", mode: ToNearestEven, output: "0.02"},
+		{description: "Negative amount", input: "-0.50", pct: "5", mode: ToNearestAway, output: "-0.03"},
+	}
+
+	for _, test := range tests {
+		d, _ := ParseDecimal(test.input)
+		pct, _ := ParseDecimal(test.pct)
+		result, err := PercentOf(d, pct, 2, test.mode)
+		checkPercentResult(t, test, result, nil, err)
+	}
+}
+
+func TestAddTaxExclusive(t *testing.T) {
+	// EU VAT guidance rounds each amount to the nearest cent, with halves
+	// rounded up. Half even rounding is shown for comparison.
+	tests := []percentTest{
+		{description: "Standard rate", input: "100.00", pct: "20", mode: ToNearestAway, output: "120.00", output2: "20.00"},
+		{description: "Rounded tax", input: "10.05", pct: "19", mode: ToNearestAway, output: "11.96", output2: "1.91"},
+		{description: "Half cent, rounded up", input: "2.50", pct: "21", mode: ToNearestAway, output: "3.03", output2: "0.53"},
+		{description: "Half cent, rounded to even", input: "2.50", pct: "21", mode: ToNearestEven, output: "3.02", output2: "0.52"},
+		{description: "Reduced rate with a fractional percentage", input: "19.99", pct: "5.5", mode: ToNearestAway, output: "21.09", output2: "1.10"},
+		{description: "Credit note", input: "-2.50", pct: "21", mode: ToNearestAway, output: "-3.03", output2: "-0.53"},
+		{description: "Zero rate", input: "9.99", pct: "0", mode: ToNearestAway, output: "9.99", output2: "0.00"},
+		{description: "Inexact", input: "2.50", pct: "21", mode: Exact, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		net, _ := ParseDecimal(test.input)
+		rate, _ := ParseDecimal(test.pct)
+		gross, tax, err := AddTaxExclusive(net, rate, 2, test.mode)
+		checkPercentResult(t, test, gross, tax, err)
+	}
+}
+
+func TestExtractTaxInclusive(t *testing.T) {
+	tests := []percentTest{
+		{description: "Standard rate", input: "120.00", pct: "20", mode: ToNearestAway, output: "100.00", output2: "20.00"},
+		{description: "Repeating fraction", input: "100.00", pct: "20", mode: ToNearestAway, output: "83.33", output2: "16.67"},
+		{description: "Round trip of a rounded tax", input: "11.96", pct: "19", mode: ToNearestAway, output: "10.05", output2: "1.91"},
+		{description: "Half cent, rounded up", input: "0.15", pct: "20", mode: ToNearestAway, output: "0.12", output2: "0.03"},
+		{description: "Half cent, rounded to even", input: "0.15", pct: "20", mode: ToNearestEven, output: "0.13", output2: "0.02"},
+		{description: "Refund", input: "-0.15", pct: "20", mode: ToNearestAway, output: "-0.12", output2: "-0.03"},
+		{description: "Rate of -100%", input: "1.00", pct: "-100", mode: ToNearestAway, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		gross, _ := ParseDecimal(test.input)
+		rate, _ := ParseDecimal(test.pct)
+		net, tax, err := ExtractTaxInclusive(gross, rate, 2, test.mode)
+		checkPercentResult(t, test, net, tax, err)
+		if err == nil {
+			// net+tax must always be gross.
+			sum := net.rat()
+			sum.Add(sum, tax.rat())
+			if sum.Cmp(gross.rat()) != 0 {
+				t.Errorf("%s: net and tax do not sum to '%s'.", test.description, test.input)
+			}
+		}
+	}
+}
+
+func TestChangePercent(t *testing.T) {
+	tests := []percentTest{
+		{description: "Increase", input: "80", pct: "100", output: "25.00"},
+		{description: "Decrease", input: "100", pct: "80", output: "-20.00"},
+		{description: "Negative values", input: "-10", pct: "-5", output: "50.00"},
+		{description: "Rounded", input: "3", pct: "4", mode: ToNearestEven, output: "33.33"},
+		{description: "No change", input: "1.50", pct: "1.5", output: "0.00"},
+		{description: "From zero", input: "0", pct: "5", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		from, _ := ParseDecimal(test.input)
+		to, _ := ParseDecimal(test.pct)
+		result, err := ChangePercent(from, to, 2, test.mode)
+		checkPercentResult(t, test, result, nil, err)
+	}
+}
+
+func checkPercentResult(t *testing.T, test percentTest, result, result2 *Decimal, err error) {
+	if err != nil {
+		if !test.shouldFail {
+			t.Errorf("%s ('%s', '%s'): expected success, received error '%v'.", test.description, test.input, test.pct, err)
+		}
+		return
+	}
+	if test.shouldFail {
+		t.Errorf("%s ('%s', '%s'): expected failure.", test.description, test.input, test.pct)
+		return
+	}
+	if result.String() != test.output {
+		t.Errorf("%s ('%s', '%s'): expected '%s', received '%s'.", test.description, test.input, test.pct, test.output, result.String())
+	}
+	if result2 != nil && result2.String() != test.output2 {
+		t.Errorf("%s ('%s', '%s'): expected '%s', received '%s'.", test.description, test.input, test.pct, test.output2, result2.String())
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// maxPowDigits bounds the number of digits in the exact powers computed by
+// PowInt and Pow. A larger positive power is far outside the range of a
+// Decimal unless its base is 1 or -1, and a larger negative power is
+// approximated instead.
+const maxPowDigits = 4096
+
+// PowInt sets d to d**n. For n >= 0 the result is exact, with n times as many
+// digits after the decimal separator as d, if a Decimal can hold it. Otherwise,
+// and for n < 0, the result is rounded to scale digits after the decimal
+// separator according to mode, as with Quo, so that 1.0425**12 can be rounded
+// to 1.65 with a scale of 2. For n < 0 it is 1 / d**-n. As with big.Int and
+// Pow, 0**0 is 1.
+//
+// ErrDivisionByZero is returned if d is zero and n is negative, ErrRange if the
+// result can not be represented, and ErrInexact if mode is Exact and the
+// result had to be rounded. On error, d is unchanged.
+func (d *Decimal) PowInt(n int64, scale int, mode RoundingMode) error {
+	if !d.Valid {
+		return ErrNotValid
+	}
+	if d.form != finite {
+		*d, _, _ = powSpecial(d, NewFromInt64(n))
+		return nil
+	}
+	num := d.String() + " ** " + strconv.FormatInt(n, 10)
+	if n < 0 && d.isZero() {
+		return &NumError{"PowInt", num, ErrDivisionByZero}
+	}
+
+	c := d.coefficient()
+	if n > 0 && (n > maxPowDigits || int64(numDigits(c))*n > maxPowDigits) {
+		// The power has too many digits to compute exactly, unless d is 1 or
+		// -1. Those still have too many after the separator for a Decimal if
+		// d has any.
+		if new(big.Int).Abs(c).Cmp(pow10(d.denominatorDigits)) != 0 {
+			return d.powIntApprox(num, d.rat(), n, scale, mode)
+		}
+		if d.denominatorDigits > 0 && n > maxExponent/int64(d.denominatorDigits) {
+			return d.powIntRound(num, d.rat(), n, scale, mode)
+		}
+	}
+	if n >= 0 {
+		p := new(big.Int).Exp(c, big.NewInt(n), nil)
+		if !d.setCoefficient(p, d.denominatorDigits*int(n)) {
+			return d.powIntRound(num, d.rat(), n, scale, mode)
+		}
+		return nil
+	}
+	if n < -maxPowDigits || -int64(numDigits(c))*n > maxPowDigits {
+		return d.powIntApprox(num, d.rat(), n, scale, mode)
+	}
+	return d.powIntRound(num, d.rat(), n, scale, mode)
+}
+
+// powIntRound sets d to x**n, rounded as PowInt does.
+func (d *Decimal) powIntRound(num string, x *big.Rat, n int64, scale int, mode RoundingMode) error {
+	if _, err := d.setRat("PowInt", ratPow(x, big.NewInt(n)), scale, mode); err != nil {
+		err.(*NumError).Num = num
+		return err
+	}
+	return nil
+}
+
+// powIntApprox sets d to x**n, rounded as PowInt does, when the power has too
+// many digits to compute exactly. It is approximated as e**(n*ln|x|) with
+// increasing precision until the rounding is certain, which is only needed for
+// x close to 1, as any other power is either too large or rounds as any value
+// below a unit in the last place does.
+func (d *Decimal) powIntApprox(num string, x *big.Rat, n int64, scale int, mode RoundingMode) error {
+	if !validScale(scale) {
+		return rangeError("PowInt", num)
+	}
+	abs := new(big.Rat).Abs(x)
+	negative := x.Sign() < 0 && n%2 != 0
+
+	// |x|**n is about 2**e, and is out of range well before e is 65.
+	lnx, _ := bigLn(new(big.Float).SetRat(abs), 64).Float64()
+	e := float64(n) * lnx / math.Ln2
+	if e > 65 {
+		return rangeError("PowInt", num)
+	}
+
+	// A positive power with this many digits is never exact.
+	if abs.Cmp(big.NewRat(1, 1)) == 0 || n < 0 && exactPow(abs, n, scale) {
+		return d.powIntRound(num, x, n, scale, mode)
+	}
+	if mode == Exact {
+		return inexactError("PowInt", num)
+	}
+
+	// Any value below 2**minExp rounds as 2**minExp does.
+	minExp := -int(float64(scale+2)*log2of10) - 2
+	prec := uint(guardBits)
+	if bits := int(float64(scale)*log2of10 + e); bits > 0 {
+		prec += uint(bits)
+	}
+
+	roundScaled := func(q *big.Rat) *big.Int {
+		n, m := new(big.Int).Set(q.Num()), new(big.Int).Set(q.Denom())
+		if scale >= 0 {
+			n.Mul(n, pow10(scale))
+		} else {
+			m.Mul(m, pow10(-scale))
+		}
+		c, _ := quoRound(n, m, mode)
+		return c
+	}
+	var c *big.Int
+	for i := 0; ; i++ {
+		wp := prec + 2*guardBits
+		t := bigLn(new(big.Float).SetPrec(wp).SetRat(abs), wp)
+		r := bigExp(t.Mul(t, new(big.Float).SetPrec(wp).SetInt64(n)), prec)
+		if r.MantExp(nil) < minExp {
+			r.SetMantExp(newFloat(prec, 1), minExp)
+		}
+		if negative {
+			r.Neg(r)
+		}
+		q, _ := r.Rat(nil)
+		bound := new(big.Rat).SetFrac(new(big.Int).Abs(q.Num()), new(big.Int).Lsh(q.Denom(), prec))
+		c = roundScaled(new(big.Rat).Sub(q, bound))
+		if c.Cmp(roundScaled(new(big.Rat).Add(q, bound))) == 0 || i == maxZivIterations {
+			break
+		}
+		prec *= 2
+	}
+	if !d.setCoefficient(c, scale) {
+		return rangeError("PowInt", num)
+	}
+	return nil
+}
+
+// exactPow reports whether x**n, for x > 0 and n < 0, may be exactly
+// representable with scale digits after the decimal separator, or lie exactly
+// halfway between two such values. Both need the numerator of x to have no
+// prime factors other than 2 and 5, to a small enough power.
+func exactPow(x *big.Rat, n int64, scale int) bool {
+	p := new(big.Int).Set(x.Num())
+	twos := int64(p.TrailingZeroBits())
+	p.Rsh(p, uint(twos))
+	fives := int64(0)
+	for m := new(big.Int); ; fives++ {
+		if q, _ := new(big.Int).QuoRem(p, big.NewInt(5), m); m.Sign() == 0 {
+			p = q
+			continue
+		}
+		break
+	}
+	limit := int64(scale) + 1
+	return p.Cmp(bigOne) == 0 && twos <= limit/-n && fives <= limit/-n
+}
+
+// Pow sets d to d**y, correctly rounded according to ctx. If y is an integer
+// the power is computed exactly before rounding. Otherwise it is computed as
+// e**(y*ln(d)), with enough precision to round correctly, and is only exact when
+// that can be proven, such as for 6.25**0.5.
+//
+// As with PowInt, any d to the power 0 is 1, including 0**0, and 1**y is
+// exactly 1. A negative d with a y that is not an integer is an invalid
+// operation with a NaN result. 0**y for negative y is a division by zero, with
+// an infinite result. d is unchanged if an error is returned.
+func (d *Decimal) Pow(y *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d, y); err != nil {
+		return err
+	}
+	num := d.String() + " ** " + y.String()
+	if result, cond, ok := powSpecial(d, y); ok {
+		return ctx.setResult("Pow", num, d, result, cond)
+	}
+
+	x := d.rat()
+	negative := d.Negative && y.isOdd()
+	if y.IsInteger() && y.numerator <= maxPowDigits && uint64(numDigits(x.Num())+numDigits(x.Denom()))*y.numerator <= maxPowDigits {
+		n := int64(y.numerator)
+		idealScale := d.denominatorDigits * int(n)
+		if y.Negative {
+			n, idealScale = -n, 0
+		}
+		c, scale := ctx.quotient(ratPow(x, big.NewInt(n)), idealScale)
+		return ctx.set("Pow", num, d, c, scale)
+	}
+
+	abs := new(big.Rat).Abs(x)
+	exponent := y.rat()
+	f := func(prec uint) *big.Float {
+		wp := prec + 2*guardBits
+		t := bigLn(new(big.Float).SetPrec(wp).SetRat(abs), wp)
+		t.Mul(t, new(big.Float).SetPrec(wp).SetRat(exponent))
+		r := bigExp(t, prec)
+		if negative {
+			r.Neg(r)
+		}
+		return r
+	}
+	exact := func(candidate *big.Rat) bool {
+		// candidate**q == x**p, where y = p/q. Powers of 1 are cheap to compute
+		// whatever p is.
+		p, q := exponent.Num(), exponent.Denom()
+		limit := big.NewInt(64)
+		if q.Cmp(limit) > 0 || p.CmpAbs(limit) > 0 && abs.Cmp(big.NewRat(1, 1)) != 0 {
+			return false
+		}
+		return ratPow(candidate, q).Cmp(ratPow(x, p)) == 0
+	}
+	return ctx.setApprox("Pow", num, d, f, exact)
+}
+
+// powSpecial returns the result of x**y if it does not need to be computed:
+// when either is a special value, or one of them is zero. The conditions
+// signalled are also returned. ok is false otherwise.
+func powSpecial(x, y *Decimal) (result Decimal, cond Condition, ok bool) {
+	if result, cond, ok = propagateNaN(x, y); ok {
+		return result, cond, true
+	}
+
+	nan := Decimal{Valid: true, form: qnan}
+	zero := Decimal{Valid: true}
+	inf := Decimal{Valid: true, form: infinite}
+	switch {
+	case y.isZero():
+		return Decimal{Valid: true, numerator: 1}, 0, true
+	case x.isZero():
+		if y.Negative {
+			return inf, DivisionByZero, true
+		}
+		return zero, 0, true
+	case x.Negative && !y.IsInteger():
+		return nan, InvalidOperation, true
+	case x.form == infinite:
+		if y.Negative {
+			return zero, 0, true
+		}
+		inf.Negative = x.Negative && y.isOdd()
+		return inf, 0, true
+	case y.form == infinite:
+		// x is finite and positive.
+		switch cmp := x.Cmp(&Decimal{Valid: true, numerator: 1}); {
+		case cmp == 0:
+			return Decimal{Valid: true, numerator: 1}, 0, true
+		case cmp > 0 != y.Negative:
+			return inf, 0, true
+		}
+		return zero, 0, true
+	case !y.IsInteger() && x.Cmp(&Decimal{Valid: true, numerator: 1}) == 0:
+		// Integer powers of 1 keep the scale of x, as other integer powers do.
+		return Decimal{Valid: true, numerator: 1}, 0, true
+	}
+	return result, 0, false
+}
+
+// isOdd reports whether d is an odd integer.
+func (d *Decimal) isOdd() bool {
+	return d.IsInteger() && d.numerator%2 == 1
+}
@@ -0,0 +1,177 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPowInt(t *testing.T) {
+	tests := []struct {
+		input  string
+		n      int64
+		scale  int
+		mode   RoundingMode
+		err    error
+		output string
+	}{
+		{input: "1.0425", n: 3, output: "1.132995515625"},
+		{input: "-1.5", n: 3, output: "-3.375"},
+		{input: "-1.5", n: 2, output: "2.25"},
+		{input: "1.10", n: 2, output: "1.2100"},
+		{input: "12.5", n: 0, output: "1.0"},
+		{input: "0", n: 0, output: "1.0"},
+		{input: "0.1", n: 19, output: "0.0000000000000000001"},
+		{input: "2", n: 63, output: "9223372036854775808.0"},
+		{input: "1.0425", n: 12, scale: 2, mode: ToNearestEven, output: "1.65"},
+		{input: "1.0425", n: 12, scale: 10, mode: ToZero, output: "1.6478313602"},
+		{input: "1.0425", n: 12, scale: 2, mode: Exact, err: ErrInexact},
+		{input: "1.0001", n: 100000, scale: 6, mode: ToNearestEven, output: "22015.456049"},
+		{input: "0.99", n: 5000, scale: 2, mode: ToPositiveInf, output: "0.01"},
+		{input: "-1.00", n: 5001, scale: 2, mode: Exact, output: "-1.00"},
+		{input: "1.5", n: -3, scale: 4, mode: ToNearestEven, output: "0.2963"},
+		{input: "-2", n: -3, scale: 4, mode: ToZero, output: "-0.1250"},
+		{input: "0.5", n: -10, scale: 0, mode: Exact, output: "1024.0"},
+		{input: "-1", n: -5001, scale: 2, mode: Exact, output: "-1.00"},
+		{input: "2", n: -5000, scale: 2, mode: ToNearestEven, output: "0.00"},
+		{input: "-2", n: -5001, scale: 2, mode: ToNegativeInf, output: "-0.01"},
+		{input: "1.001", n: -2000, scale: 6, mode: ToNearestEven, output: "0.135471"},
+		{input: "0.999", n: -2000, scale: 6, mode: ToNearestEven, output: "7.396454"},
+		{input: "-0.999", n: -2001, scale: 6, mode: ToZero, output: "-7.403857"},
+		{input: "1", n: math.MinInt64, scale: 2, mode: ToNearestEven, output: "1.00"},
+		{input: "-1", n: math.MinInt64, scale: 2, mode: Exact, output: "1.00"},
+		{input: "1.0000000000000000001", n: -1000000000000000000, scale: 10, mode: ToNearestEven, output: "0.9048374180"},
+		{input: "-1", n: 5001, output: "-1.0"},
+		{input: "1", n: 5001, output: "1.0"},
+		{input: "-1.0", n: 5001, output: "-1." + strings.Repeat("0", 5001)},
+		{input: "10", n: -5000, scale: 5000, mode: Exact, output: "0." + strings.Repeat("0", 4999) + "1"},
+		{input: "0.999", n: -2000, scale: 6, mode: Exact, err: ErrInexact},
+		{input: "Inf", n: -2, output: "0.0"},
+		{input: "-Inf", n: 3, output: "-Infinity"},
+		{input: "sNaN", n: 2, output: "NaN"},
+		{input: "0", n: -1, scale: 2, mode: ToNearestEven, err: ErrDivisionByZero},
+		{input: "3", n: -1, scale: 2, mode: Exact, err: ErrInexact},
+		{input: "2", n: 64, err: ErrRange},
+		{input: "0.1", n: 5000, scale: 2, mode: ToNearestEven, output: "0.00"},
+		{input: "0.1", n: 5000, scale: 2, mode: Exact, err: ErrInexact},
+		{input: "0.5", n: -5000, scale: 2, mode: ToNearestEven, err: ErrRange},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		before := *d
+		err = d.PowInt(test.n, test.scale, test.mode)
+		if test.err != nil {
+			if err == nil || err.(*NumError).Err != test.err {
+				t.Errorf("'%s ** %d': Expected error '%v', received '%v'.", test.input, test.n, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("'%s ** %d': Expected the value to be unchanged on error, received '%s'.", test.input, test.n, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("'%s ** %d': Expected '%s', received '%s' (error '%v').", test.input, test.n, test.output, d.String(), err)
+		}
+	}
+
+	if err := (&Decimal{}).PowInt(2, 0, ToNearestEven); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
+
+func TestPow(t *testing.T) {
+	basic := Context{Precision: 16}
+
+	tests := []struct {
+		ctx          Context
+		input, power string
+		output       string
+		flags, err   Condition
+	}{
+		// Integer powers are exact before rounding.
+		{ctx: basic, input: "1.0425", power: "12", output: "1.647831360223648", flags: Inexact | Rounded},
+		{ctx: basic, input: "-2", power: "3", output: "-8.0"},
+		{ctx: basic, input: "1.5", power: "-3", output: "0.2962962962962963", flags: Inexact | Rounded},
+		{ctx: basic, input: "10", power: "-2", output: "0.01"},
+		{ctx: Context{Precision: 3, Emax: 9, ExponentLimits: true}, input: "3", power: "100", output: "Infinity", flags: Overflow | Inexact | Rounded},
+
+		// Other powers are correctly rounded.
+		{ctx: basic, input: "1.0425", power: "2.5", output: "1.109660582161601", flags: Inexact | Rounded},
+		{ctx: basic, input: "2", power: "0.5", output: "1.414213562373095", flags: Inexact | Rounded},
+		{ctx: basic, input: "2", power: "-0.5", output: "0.7071067811865475", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 10, Rounding: ToZero}, input: "2", power: "-0.5", output: "0.7071067811", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 10, Rounding: ToPositiveInf}, input: "2", power: "-0.5", output: "0.7071067812", flags: Inexact | Rounded},
+		{ctx: Context{}, input: "1.0425", power: "12.5", output: "1.6824834293048236721", flags: Clamped | Inexact | Rounded},
+		{ctx: Context{}, input: "0.001", power: "1.5", output: "0.00003162277660168379332", flags: Clamped | Inexact | Rounded},
+		{ctx: DefaultContext, input: "1", power: "-9223372036854775808", output: "1.0"},
+		{ctx: DefaultContext, input: "1.0000001", power: "1000000000.5", flags: Overflow | Inexact | Rounded, err: Overflow},
+
+		// Exact results are recognised, even when directed rounding would
+		// otherwise be ambiguous.
+		{ctx: basic, input: "6.25", power: "0.5", output: "2.5"},
+		{ctx: Context{Precision: 10, Rounding: ToZero}, input: "0.0001", power: "0.25", output: "0.1"},
+		{ctx: Context{Precision: 10, Rounding: ToNegativeInf}, input: "1", power: "0.3", output: "1.0"},
+		{ctx: basic, input: "8", power: "-1.5", output: "0.04419417382415922", flags: Inexact | Rounded},
+
+		// Special values and domain errors.
+		{ctx: basic, input: "-8", power: "0.5", output: "NaN", flags: InvalidOperation},
+		{ctx: DefaultContext, input: "-8", power: "0.5", flags: InvalidOperation, err: InvalidOperation},
+		{ctx: basic, input: "0", power: "-1", output: "Infinity", flags: DivisionByZero},
+		{ctx: DefaultContext, input: "0", power: "-2.5", flags: DivisionByZero, err: DivisionByZero},
+		{ctx: basic, input: "0", power: "0", output: "1.0"},
+		{ctx: DefaultContext, input: "1", power: "0.0000000000000000001", output: "1.0"},
+		{ctx: DefaultContext, input: "1.00", power: "-12345.678", output: "1.0"},
+		{ctx: basic, input: "0", power: "2.5", output: "0.0"},
+		{ctx: basic, input: "Inf", power: "0", output: "1.0"},
+		{ctx: basic, input: "-Inf", power: "3", output: "-Infinity"},
+		{ctx: basic, input: "-Inf", power: "-2", output: "0.0"},
+		{ctx: basic, input: "0.5", power: "Inf", output: "0.0"},
+		{ctx: basic, input: "0.5", power: "-Inf", output: "Infinity"},
+		{ctx: basic, input: "-0.5", power: "Inf", output: "NaN", flags: InvalidOperation},
+		{ctx: basic, input: "NaN", power: "0", output: "NaN"},
+		{ctx: basic, input: "2", power: "sNaN", output: "NaN", flags: InvalidOperation},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		y, err := ParseDecimal(test.power)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.power, err)
+		}
+		before := *d
+
+		ctx := test.ctx
+		err = d.Pow(y, &ctx)
+		if ctx.Flags != test.flags {
+			t.Errorf("'%s ** %s': Expected flags '%v', received '%v'.", test.input, test.power, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("'%s ** %s': Expected error '%v', received '%v'.", test.input, test.power, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("'%s ** %s': Expected the value to be unchanged on error, received '%s'.", test.input, test.power, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("'%s ** %s': Expected '%s', received '%s' (error '%v').", test.input, test.power, test.output, d.String(), err)
+		}
+	}
+
+	ctx := DefaultContext
+	if err := (&Decimal{}).Pow(NewFromInt64(2), &ctx); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
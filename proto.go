@@ -0,0 +1,91 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// The number of nanos in a unit, as used by google.type.Money.
+const (
+	nanosPerUnit = 1000000000
+	nanosDigits  = 9
+)
+
+// ParseProtoDecimal converts the string s, which must be the value of a
+// google.type.Decimal message, into a Decimal. The grammar is:
+//
+// [Sign] Significand [Exponent]
+//
+// Sign is a negative (-) or positive (+) sign
+// Significand is Digits ['.'] [Digits] or [Digits] '.' Digits
+// Exponent is 'e' or 'E', followed by an optional Sign and Digits
+//
+// The decimal separator is always '.', regardless of DecimalSeparator.
+// Whitespace, thousands separators, NaN and Infinity are rejected.
+func ParseProtoDecimal(s string) (*Decimal, error) {
+	return parseScientific("ParseProtoDecimal", s)
+}
+
+// ProtoDecimal returns the string representation of d, suitable for use as
+// the value of a google.type.Decimal message. The decimal separator is always
+// '.', regardless of DecimalSeparator. ErrNotFinite is returned if d is NaN or
+// an infinity, and ErrNotValid if d is not Valid, as neither has a
+// google.type.Decimal representation.
+func (d *Decimal) ProtoDecimal() (string, error) {
+	if err := d.checkFinite(); err != nil {
+		return "", err
+	}
+	return d.string('.'), nil
+}
+
+// NewFromUnitsNanos converts the units and nanos of a google.type.Money message
+// into a Decimal. nanos must be between -999,999,999 and +999,999,999, and
+// must have the same sign as units when units is non-zero. ErrRange is
+// returned otherwise.
+func NewFromUnitsNanos(units int64, nanos int32) (*Decimal, error) {
+	const fnName = "NewFromUnitsNanos"
+
+	input := strconv.FormatInt(units, 10) + ", " + strconv.FormatInt(int64(nanos), 10)
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit {
+		return nil, rangeError(fnName, input)
+	}
+	if units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return nil, rangeError(fnName, input)
+	}
+
+	c := big.NewInt(units)
+	c.Mul(c, big.NewInt(nanosPerUnit))
+	c.Add(c, big.NewInt(int64(nanos)))
+	c, scale := trimZeros(c, nanosDigits)
+	decimal := &Decimal{}
+	decimal.setCoefficient(c, scale)
+	return decimal, nil
+}
+
+// UnitsNanos converts d into the units and nanos of a google.type.Money
+// message. Both values share the sign of d. If d has more than nine
+// fractional digits, it is rounded according to mode. If mode is Exact,
+// ErrInexact is returned instead. ErrRange is returned if the units do not fit
+// in an int64.
+func (d *Decimal) UnitsNanos(mode RoundingMode) (units int64, nanos int32, err error) {
+	const fnName = "UnitsNanos"
+
+	if err := d.checkFinite(); err != nil {
+		return 0, 0, err
+	}
+	c, exact := rescale(d.coefficient(), d.denominatorDigits, nanosDigits, mode)
+	if !exact && mode == Exact {
+		return 0, 0, inexactError(fnName, d.String())
+	}
+
+	// QuoRem truncates towards zero, so both parts share the sign of c.
+	u, n := new(big.Int).QuoRem(c, big.NewInt(nanosPerUnit), new(big.Int))
+	if !u.IsInt64() {
+		return 0, 0, rangeError(fnName, d.String())
+	}
+	return u.Int64(), int32(n.Int64()), nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestParseProtoDecimal(t *testing.T) {
+	tests := map[string]string{
+		"0":          "0.0",
+		"-0":         "0.0",
+		"123":        "123.0",
+		"+123":       "123.0",
+		"-123.45":    "-123.45",
+		"2.":         "2.0",
+		".5":         "0.5",
+		"-.5":        "-0.5",
+		"1.50":       "1.50",
+		"1.5e2":      "150.0",
+		"1.5E+2":     "150.0",
+		"15e-1":      "1.5",
+		"-1.25e-3":   "-0.00125",
+		"1e19":       "10000000000000000000.0",
+		"0.00000001": "0.00000001",
+	}
+
+	for input, output := range tests {
+		d, err := ParseProtoDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if s, err := d.ProtoDecimal(); err != nil || s != output {
+			t.Errorf("'%s': Expected '%s', received '%s' (error '%v').", input, output, s, err)
+		}
+	}
+
+	failures := []string{
+		"",
+		"+",
+		".",
+		"-.",
+		"1,5",
+		" 1.5",
+		"1.5 ",
+		"1.2.3",
+		"1e",
+		"1e+",
+		"1e1.5",
+		"e5",
+		"NaN",
+		"Infinity",
+		"1_000",
+		"1e20",
+		"1e100000",
+	}
+	for _, input := range failures {
+		if d, err := ParseProtoDecimal(input); err == nil {
+			t.Errorf("'%s': Expected failure, received '%s'.", input, d.String())
+		}
+	}
+}
+
+func TestProtoDecimal(t *testing.T) {
+	d, err := ParseDecimal("-1.50")
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if s, err := d.ProtoDecimal(); err != nil || s != "-1.50" {
+		t.Errorf("Expected '-1.50', received '%s' (error '%v').", s, err)
+	}
+
+	for _, d := range []*Decimal{NaN(), SignalingNaN(), Inf(1), Inf(-1)} {
+		if s, err := d.ProtoDecimal(); err != ErrNotFinite {
+			t.Errorf("'%s': Expected ErrNotFinite, received '%s' (error '%v').", d.String(), s, err)
+		}
+	}
+	if _, err := (&Decimal{}).ProtoDecimal(); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
+
+func TestProtoDecimalSeparator(t *testing.T) {
+	defer func(separator rune) { DecimalSeparator = separator }(DecimalSeparator)
+
+	d, err := ParseDecimal("1.5")
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	DecimalSeparator = ','
+	if s, err := d.ProtoDecimal(); err != nil || s != "1.5" {
+		t.Errorf("Expected '1.5', received '%s' (error '%v').", s, err)
+	}
+	if d.String() != "1,5" {
+		t.Errorf("Expected '1,5', received '%s'.", d.String())
+	}
+}
+
+func TestNewFromUnitsNanos(t *testing.T) {
+	type unitsNanosTest struct {
+		units      int64
+		nanos      int32
+		shouldFail bool
+		output     string
+	}
+
+	tests := []unitsNanosTest{
+		{units: 5, nanos: 0, output: "5.0"},
+		{units: 1, nanos: 500000000, output: "1.5"},
+		{units: 0, nanos: 1, output: "0.000000001"},
+		{units: -1, nanos: -750000000, output: "-1.75"},
+		{units: 0, nanos: -10000000, output: "-0.01"},
+		{units: 0, nanos: 0, output: "0.0"},
+		{units: -9223372036854775808, nanos: -999999999, output: "-9223372036854775808.999999999"},
+		{units: 1, nanos: -1, shouldFail: true},
+		{units: -1, nanos: 1, shouldFail: true},
+		{units: 0, nanos: 1000000000, shouldFail: true},
+		{units: 0, nanos: -1000000000, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, err := NewFromUnitsNanos(test.units, test.nanos)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("(%d, %d): expected success, received error '%v'.", test.units, test.nanos, err)
+			} else if err.(*NumError).Err != ErrRange {
+				t.Errorf("(%d, %d): expected ErrRange, received '%v'.", test.units, test.nanos, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("(%d, %d): expected failure.", test.units, test.nanos)
+			continue
+		}
+		if d.String() != test.output {
+			t.Errorf("(%d, %d): expected '%s', received '%s'.", test.units, test.nanos, test.output, d.String())
+		}
+	}
+}
+
+func TestUnitsNanos(t *testing.T) {
+	type unitsNanosTest struct {
+		input      string
+		mode       RoundingMode
+		shouldFail bool
+		units      int64
+		nanos      int32
+	}
+
+	tests := []unitsNanosTest{
+		{input: "5", units: 5},
+		{input: "1.5", units: 1, nanos: 500000000},
+		{input: "-1.75", units: -1, nanos: -750000000},
+		{input: "-0.01", units: 0, nanos: -10000000},
+		{input: "0.0000000015", mode: ToNearestEven, units: 0, nanos: 2},
+		{input: "0.0000000025", mode: ToNearestEven, units: 0, nanos: 2},
+		{input: "-0.0000000015", mode: ToZero, units: 0, nanos: -1},
+		{input: "0.9999999999", mode: ToNearestEven, units: 1, nanos: 0},
+		{input: "0.0000000015", mode: Exact, shouldFail: true},
+		{input: "9223372036854775807.999999999", mode: Exact, units: 9223372036854775807, nanos: 999999999},
+		{input: "9223372036854775808", shouldFail: true},
+		{input: "-9223372036854775809", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		units, nanos, err := d.UnitsNanos(test.mode)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s': Expected failure.", test.input)
+			continue
+		}
+		if units != test.units || nanos != test.nanos {
+			t.Errorf("'%s': Expected (%d, %d), received (%d, %d).", test.input, test.units, test.nanos, units, nanos)
+		}
+	}
+}
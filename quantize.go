@@ -0,0 +1,120 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+// Rescale sets d to its value with exactly scale digits after the decimal
+// separator. Zeros are appended when the scale is increased, and the value is
+// rounded according to mode when it is decreased. A negative scale rounds to a
+// multiple of 10**-scale; for example, rescaling 1250 to a scale of -2 with
+// ToNearestEven gives 1200.
+//
+// ErrInexact is returned if rounding is required and mode is Exact, and
+// ErrRange is returned if the result can not be represented by a Decimal, such
+// as when the integer part does not fit or scale is less than -10000 or more
+// than 10000. d is unchanged on error. NaN and infinities are unchanged, except
+// that a signaling NaN becomes quiet.
+func (d *Decimal) Rescale(scale int, mode RoundingMode) error {
+	if !d.Valid {
+		return ErrNotValid
+	}
+	if d.form != finite {
+		if d.form == snan {
+			d.form = qnan
+		}
+		return nil
+	}
+	return d.setScale("Rescale", scale, mode)
+}
+
+// Quantize sets d to its value with the same number of digits after the
+// decimal separator as exp, as described by Rescale. For example, quantizing
+// 3.14159 to 0.01 gives 3.14, and 3 to 0.01 gives 3.00. Only the scale of exp
+// is used, not its value.
+//
+// If either d or exp is NaN, d is set to NaN. An infinite d is unchanged if exp
+// is also infinite, and otherwise d is set to NaN, as the result has no
+// meaningful scale.
+func (d *Decimal) Quantize(exp *Decimal, mode RoundingMode) error {
+	if !d.Valid || !exp.Valid {
+		return ErrNotValid
+	}
+	if result, _, ok := quantizeSpecial(d, exp); ok {
+		*d = result
+		return nil
+	}
+	return d.setScale("Quantize", exp.denominatorDigits, mode)
+}
+
+// setScale implements Rescale for a finite d.
+func (d *Decimal) setScale(fnName string, scale int, mode RoundingMode) error {
+	if !validScale(scale) {
+		return rangeError(fnName, d.String())
+	}
+	c, exact := rescale(d.coefficient(), d.denominatorDigits, scale, mode)
+	if !exact && mode == Exact {
+		return inexactError(fnName, d.String())
+	}
+	var result Decimal
+	if !result.setCoefficient(c, scale) {
+		return rangeError(fnName, d.String())
+	}
+	*d = result
+	return nil
+}
+
+// quantizeSpecial returns the result of quantizing d to exp if either is a
+// special value, along with the conditions signalled. ok is false if both are
+// finite.
+func quantizeSpecial(d, exp *Decimal) (result Decimal, cond Condition, ok bool) {
+	if d.form == finite && exp.form == finite {
+		return result, 0, false
+	}
+	if result, cond, ok = propagateNaN(d, exp); ok {
+		return result, cond, true
+	}
+	if d.form == infinite && exp.form == infinite {
+		return *d, 0, true
+	}
+	return Decimal{Valid: true, form: qnan}, InvalidOperation, true
+}
+
+// QuantizeContext sets d to its value with the same number of digits after the
+// decimal separator as exp, rounding according to ctx.Rounding. Rounded, and
+// Inexact if non-zero digits are discarded, are signalled when the scale is
+// decreased. InvalidOperation is signalled if the result would have more than
+// ctx.Precision significant digits, or can not be represented by a Decimal.
+// Special values are handled as with Quantize, except that InvalidOperation is
+// signalled where Quantize gives NaN.
+//
+// Conditions are recorded in ctx.Flags, and an error is returned if one is
+// trapped, or if either d or exp are flagged as being invalid. d is unchanged
+// on error.
+func (d *Decimal) QuantizeContext(exp *Decimal, ctx *Context) error {
+	const fnName = "QuantizeContext"
+
+	if err := ctx.checkValid(d, exp); err != nil {
+		return err
+	}
+	num := d.String() + " quantize " + exp.String()
+	if result, cond, ok := quantizeSpecial(d, exp); ok {
+		return ctx.setResult(fnName, num, d, result, cond)
+	}
+
+	scale := exp.denominatorDigits
+	c, exact := rescale(d.coefficient(), d.denominatorDigits, scale, ctx.Rounding)
+	var cond Condition
+	if scale < d.denominatorDigits {
+		cond |= Rounded
+		if !exact {
+			cond |= Inexact
+		}
+	}
+
+	var result Decimal
+	if ctx.Precision > 0 && numDigits(c) > ctx.Precision || !result.setCoefficient(c, scale) {
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, form: qnan}, InvalidOperation)
+	}
+	return ctx.setResult(fnName, num, d, result, cond)
+}
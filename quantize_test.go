@@ -0,0 +1,125 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestRescale(t *testing.T) {
+	tests := []struct {
+		input  string
+		scale  int
+		mode   RoundingMode
+		err    error
+		output string
+	}{
+		{input: "3.14159", scale: 2, mode: ToNearestEven, output: "3.14"},
+		{input: "3", scale: 2, mode: Exact, output: "3.00"},
+		{input: "2.5", scale: 0, mode: ToNearestEven, output: "2.0"},
+		{input: "-2.5", scale: 0, mode: AwayFromZero, output: "-3.0"},
+		{input: "-0.004", scale: 2, mode: ToNearestEven, output: "0.00"},
+		{input: "1250", scale: -2, mode: ToNearestEven, output: "1200.0"},
+		{input: "1.1", scale: 20, mode: Exact, output: "1.10000000000000000000"},
+		{input: "Inf", scale: 2, mode: ToNearestEven, output: "Infinity"},
+		{input: "sNaN", scale: 2, mode: ToNearestEven, output: "NaN"},
+		{input: "1.005", scale: 2, mode: Exact, err: ErrInexact},
+		{input: "1.9", scale: 20, mode: Exact, err: ErrRange},
+		{input: "0", scale: 1 << 20, mode: Exact, err: ErrRange},
+		{input: "1.9", scale: -1 << 40, mode: ToNearestEven, err: ErrRange},
+		{input: "18446744073709551615.5", scale: 0, mode: ToPositiveInf, err: ErrRange},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		before := *d
+		err = d.Rescale(test.scale, test.mode)
+		if test.err != nil {
+			if err == nil || err.(*NumError).Err != test.err {
+				t.Errorf("'%s': Expected error '%v', received '%v'.", test.input, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("'%s': Expected the value to be unchanged on error, received '%s'.", test.input, d.String())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			continue
+		}
+		if d.String() != test.output {
+			t.Errorf("'%s': Expected '%s', received '%s'.", test.input, test.output, d.String())
+		}
+	}
+
+	if err := (&Decimal{}).Rescale(2, ToNearestEven); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	tests := map[[2]string]string{
+		{"3.14159", "0.01"}:   "3.14",
+		{"3", "0.01"}:         "3.00",
+		{"-1.2345", "100.0"}:  "-1.2",
+		{"7.50", "1"}:         "8.0",
+		{"Inf", "-Infinity"}:  "Infinity",
+		{"Inf", "1.00"}:       "NaN",
+		{"1.00", "Inf"}:       "NaN",
+		{"1", "NaN"}:          "NaN",
+		{"-sNaN", "1"}:        "-NaN",
+		{"12.3456", "0.0000"}: "12.3456",
+	}
+
+	for inputs, output := range tests {
+		d, _ := ParseDecimal(inputs[0])
+		exp, _ := ParseDecimal(inputs[1])
+		if err := d.Quantize(exp, ToNearestEven); err != nil || d.String() != output {
+			t.Errorf("'%s' to '%s': Expected '%s', received '%s' (error '%v').", inputs[0], inputs[1], output, d.String(), err)
+		}
+	}
+}
+
+func TestQuantizeContext(t *testing.T) {
+	tests := []struct {
+		ctx        Context
+		input, exp string
+		output     string
+		flags, err Condition
+	}{
+		{ctx: Context{Precision: 5}, input: "123.456", exp: "0.01", output: "123.46", flags: Rounded | Inexact},
+		{ctx: Context{Precision: 5}, input: "1.20", exp: "0.1", output: "1.2", flags: Rounded},
+		{ctx: Context{Precision: 5}, input: "1.2", exp: "0.001", output: "1.200"},
+		{ctx: Context{Precision: 5}, input: "123.4", exp: "0.001", output: "NaN", flags: InvalidOperation},
+		{ctx: DefaultContext, input: "123.4", exp: "0.00000000000000000001", flags: InvalidOperation, err: InvalidOperation},
+		{ctx: DefaultContext, input: "Inf", exp: "1", flags: InvalidOperation, err: InvalidOperation},
+		{ctx: Context{Precision: 5, Traps: Inexact}, input: "1.25", exp: "0.1", flags: Rounded | Inexact, err: Inexact},
+	}
+
+	for _, test := range tests {
+		d, _ := ParseDecimal(test.input)
+		exp, _ := ParseDecimal(test.exp)
+		before := *d
+
+		ctx := test.ctx
+		err := d.QuantizeContext(exp, &ctx)
+		if ctx.Flags != test.flags {
+			t.Errorf("'%s' to '%s': Expected flags '%v', received '%v'.", test.input, test.exp, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("'%s' to '%s': Expected error '%v', received '%v'.", test.input, test.exp, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("'%s' to '%s': Expected the value to be unchanged on error, received '%s'.", test.input, test.exp, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("'%s' to '%s': Expected '%s', received '%s' (error '%v').", test.input, test.exp, test.output, d.String(), err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+)
+
+// Sqrt sets d to the square root of d, correctly rounded according to ctx. An
+// exact result has as few digits after the decimal separator as possible, but
+// no fewer than half as many as d, so the square root of 6.25 is 2.5 and the
+// square root of 0.0400 is 0.20.
+//
+// The square root of a negative value is an invalid operation, with a NaN
+// result. d is unchanged if an error is returned.
+func (d *Decimal) Sqrt(ctx *Context) error {
+	return d.root("Sqrt", 2, ctx)
+}
+
+// Root sets d to the n-th root of d, correctly rounded according to ctx, with
+// exact results treated as for Sqrt. Odd roots of negative values are
+// negative.
+//
+// An even root of a negative value, and n < 1, are invalid operations with a
+// NaN result. d is unchanged if an error is returned.
+func (d *Decimal) Root(n int, ctx *Context) error {
+	return d.root("Root", n, ctx)
+}
+
+// root implements Sqrt and Root, reporting fnName as the failing function.
+func (d *Decimal) root(fnName string, n int, ctx *Context) error {
+	if err := ctx.checkValid(d, d); err != nil {
+		return err
+	}
+	num := d.String()
+	if result, cond, ok := propagateNaN(d, d); ok {
+		return ctx.setResult(fnName, num, d, result, cond)
+	}
+	if n < 1 || d.Negative && n%2 == 0 {
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, form: qnan}, InvalidOperation)
+	}
+	if d.form == infinite {
+		return ctx.setResult(fnName, num, d, *d, 0)
+	}
+
+	// The ideal scale of an exact result.
+	idealScale := d.denominatorDigits / n
+	if d.denominatorDigits%n != 0 {
+		idealScale++
+	}
+	if d.isZero() {
+		return ctx.set(fnName, num, d, new(big.Int), idealScale)
+	}
+
+	// Large roots would need integers with n times as many digits as the
+	// result, and n*scale could overflow, so they are approximated instead.
+	if n > maxPowDigits {
+		return d.rootApprox(fnName, num, n, ctx)
+	}
+
+	// Compute the root of c * 10**-s as the integer root of
+	// c * 10**(n*scale - s), which is the result scaled by 10**scale. scale is
+	// chosen so that there is at least one more digit than will be kept.
+	// Without a precision, that depends on the leading zeros of the fractional
+	// part of the root, so scale is increased until there are enough.
+	c := new(big.Int).Abs(d.coefficient())
+	s := d.denominatorDigits
+	scale := maxScale + 1
+	if ctx.Precision > 0 {
+		scale = (n*ctx.Precision + 1 + s - numDigits(c) + n - 1) / n
+	}
+	if scale < idealScale {
+		scale = idealScale
+	}
+	var x, r *big.Int
+	exact := false
+	for {
+		x = new(big.Int).Mul(c, pow10(n*scale-s))
+		r = intRoot(x, n)
+		exact = new(big.Int).Exp(r, big.NewInt(int64(n)), nil).Cmp(x) == 0
+		if exact || ctx.Precision > 0 || fractionDigits(r, scale) > maxScale || scale > maxExponent {
+			break
+		}
+		scale += maxScale
+	}
+	if exact {
+		ten, rem := big.NewInt(10), new(big.Int)
+		for scale > idealScale {
+			if q, _ := new(big.Int).QuoRem(r, ten, rem); rem.Sign() == 0 {
+				r, scale = q, scale-1
+				continue
+			}
+			break
+		}
+	} else {
+		// A non-zero sticky digit stands in for those that were discarded.
+		r.Mul(r, bigTen)
+		r.Add(r, bigOne)
+		scale++
+	}
+	if d.Negative {
+		r.Neg(r)
+	}
+	return ctx.set(fnName, num, d, r, scale)
+}
+
+// rootApprox sets d to the n-th root of d, which is finite, positive or odd,
+// and not zero, as e**(ln|d|/n) correctly rounded according to ctx. It is used
+// for large n, where the root is exact only if it is a power of ten or d is 1.
+func (d *Decimal) rootApprox(fnName, num string, n int, ctx *Context) error {
+	x := d.rat()
+	abs := new(big.Rat).Abs(x)
+	f := func(prec uint) *big.Float {
+		wp := prec + 2*guardBits
+		t := bigLn(new(big.Float).SetPrec(wp).SetRat(abs), wp)
+		r := bigExp(t.Quo(t, newFloat(wp, int64(n))), prec)
+		if x.Sign() < 0 {
+			r.Neg(r)
+		}
+		return r
+	}
+	exact := func(candidate *big.Rat) bool {
+		// candidate**n has at least n*(bitlen-1) bits in its numerator and
+		// denominator, which can not be more than those of x.
+		p, q := candidate.Num(), candidate.Denom()
+		if p.BitLen()-1 > x.Num().BitLen()/n || q.BitLen()-1 > x.Denom().BitLen()/n {
+			return false
+		}
+		return ratPow(candidate, big.NewInt(int64(n))).Cmp(x) == 0
+	}
+	return ctx.setApprox(fnName, num, d, f, exact)
+}
+
+// intRoot returns the largest integer r such that r**n <= x, for x >= 0 and
+// n >= 1, using Newton's iteration.
+func intRoot(x *big.Int, n int) *big.Int {
+	if x.Sign() == 0 || n == 1 {
+		return new(big.Int).Set(x)
+	}
+
+	// Start from an estimate of the root with a relative error of about
+	// 2**-50, so that the iteration converges quadratically from the start.
+	// x = m * 2**e, with m in [0.5, 1), so the root is 2**((log2(m)+e)/n).
+	m := new(big.Float).SetInt(x)
+	e := m.MantExp(m)
+	mf, _ := m.Float64()
+	exp := (math.Log2(mf) + float64(e)) / float64(n)
+	whole := math.Floor(exp)
+	r, _ := new(big.Float).SetMantExp(big.NewFloat(math.Exp2(exp-whole)), int(whole)).Int(nil)
+	if r.Sign() == 0 {
+		r.SetInt64(1)
+	}
+
+	bigN, bigN1 := big.NewInt(int64(n)), big.NewInt(int64(n-1))
+	next, power := new(big.Int), new(big.Int)
+	for i := 0; ; i++ {
+		// next = ((n-1)*r + x/r**(n-1)) / n
+		power.Exp(r, bigN1, nil)
+		next.Quo(x, power)
+		next.Add(next, power.Mul(r, bigN1))
+		next.Quo(next, bigN)
+		// The estimate may be below the root, but every step ends at or
+		// above the floor of the root, from which the iteration decreases
+		// monotonically until it reaches it.
+		if i > 0 && next.Cmp(r) >= 0 {
+			return r
+		}
+		r, next = next, r
+	}
+}
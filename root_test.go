@@ -0,0 +1,116 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestRoot(t *testing.T) {
+	basic := Context{Precision: 16}
+
+	tests := []struct {
+		ctx        Context
+		input      string
+		n          int
+		output     string
+		flags, err Condition
+	}{
+		{ctx: basic, input: "2", n: 2, output: "1.414213562373095", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 10, Rounding: ToPositiveInf}, input: "2", n: 2, output: "1.414213563", flags: Inexact | Rounded},
+		{ctx: Context{}, input: "2", n: 2, output: "1.4142135623730950488", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, input: "6.25", n: 2, output: "2.5"},
+		{ctx: basic, input: "0.0400", n: 2, output: "0.20"},
+		{ctx: basic, input: "100", n: 2, output: "10.0"},
+		{ctx: Context{Precision: 1}, input: "100", n: 2, output: "10.0", flags: Rounded},
+		{ctx: basic, input: "0.00000000000000000001", n: 2, output: "0.0000000001"},
+		{ctx: basic, input: "18446744073709551615", n: 2, output: "4294967296.000000", flags: Inexact | Rounded},
+		{ctx: basic, input: "0.000", n: 2, output: "0.00"},
+		{ctx: basic, input: "27", n: 3, output: "3.0"},
+		{ctx: basic, input: "-0.008", n: 3, output: "-0.2"},
+		{ctx: basic, input: "1.0425", n: 12, output: "1.003474495003498", flags: Inexact | Rounded},
+		{ctx: basic, input: "2", n: 1, output: "2.0"},
+		{ctx: DefaultContext, input: "2", n: 1000, output: "1.000693387462580633", flags: Inexact | Rounded},
+		{ctx: DefaultContext, input: "2", n: 3000, output: "1.000231075754076581", flags: Inexact | Rounded},
+		{ctx: DefaultContext, input: "2", n: 10000, output: "1.000069317120376569", flags: Inexact | Rounded},
+		{ctx: DefaultContext, input: "2", n: math.MaxInt32, output: "1.000000000322771809", flags: Inexact | Rounded},
+		{ctx: basic, input: "0." + strings.Repeat("0", 4999) + "1", n: 5000, output: "0.1"},
+		{ctx: basic, input: "-1.0", n: 4097, output: "-1.0"},
+		{ctx: basic, input: "Inf", n: 2, output: "Infinity"},
+		{ctx: basic, input: "-Inf", n: 3, output: "-Infinity"},
+		{ctx: basic, input: "-Inf", n: 2, output: "NaN", flags: InvalidOperation},
+		{ctx: basic, input: "-4", n: 2, output: "NaN", flags: InvalidOperation},
+		{ctx: DefaultContext, input: "-4", n: 2, flags: InvalidOperation, err: InvalidOperation},
+		{ctx: basic, input: "4", n: 0, output: "NaN", flags: InvalidOperation},
+		{ctx: basic, input: "sNaN", n: 2, output: "NaN", flags: InvalidOperation},
+		{ctx: Context{Precision: 16, Traps: Inexact}, input: "3", n: 2, flags: Inexact | Rounded, err: Inexact},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		before := *d
+
+		ctx := test.ctx
+		if test.n == 2 {
+			err = d.Sqrt(&ctx)
+		} else {
+			err = d.Root(test.n, &ctx)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("'%s', %d: Expected flags '%v', received '%v'.", test.input, test.n, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("'%s', %d: Expected error '%v', received '%v'.", test.input, test.n, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("'%s', %d: Expected the value to be unchanged on error, received '%s'.", test.input, test.n, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("'%s', %d: Expected '%s', received '%s' (error '%v').", test.input, test.n, test.output, d.String(), err)
+		}
+	}
+
+	ctx := DefaultContext
+	if err := (&Decimal{}).Sqrt(&ctx); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
+
+func TestSqrtMatchesBigFloat(t *testing.T) {
+	inputs := []string{"2", "3", "0.5", "10", "123.456", "0.0000001", "99999999999999.9999", "18446744073709551615.5", "1.0000000000000000001"}
+
+	for _, input := range inputs {
+		for _, precision := range []int{1, 7, 16, 19} {
+			d, _ := ParseDecimal(input)
+			ctx := Context{Precision: precision}
+			if err := d.Sqrt(&ctx); err != nil {
+				t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+				continue
+			}
+
+			// Rounding the square root from big.Float, computed with far more
+			// precision than needed, must give the same result.
+			x, _ := ParseDecimal(input)
+			f := new(big.Float).SetPrec(512).SetRat(x.rat())
+			f.Sqrt(f)
+			q, _ := f.Rat(nil)
+			c, scale, _ := ctx.roundRat(q)
+			expected := new(Decimal)
+			expected.setCoefficient(c, scale)
+			if d.Cmp(expected) != 0 {
+				t.Errorf("'%s' to %d digits: Expected '%s', received '%s'.", input, precision, expected.String(), d.String())
+			}
+		}
+	}
+}
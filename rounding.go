@@ -0,0 +1,92 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode determines how a value is rounded when digits must be
+// discarded. The modes mirror those of math/big, with the addition of Exact.
+type RoundingMode byte
+
+// The available rounding modes.
+const (
+	ToNearestEven RoundingMode = iota // == IEEE 754-2008 roundTiesToEven
+	ToNearestAway                     // == IEEE 754-2008 roundTiesToAway
+	ToZero                            // == IEEE 754-2008 roundTowardZero
+	AwayFromZero                      // no IEEE 754-2008 equivalent
+	ToNegativeInf                     // == IEEE 754-2008 roundTowardNegative
+	ToPositiveInf                     // == IEEE 754-2008 roundTowardPositive
+	Exact                             // digits may not be discarded; ErrInexact is returned instead
+)
+
+var roundingModeNames = [...]string{
+	ToNearestEven: "ToNearestEven",
+	ToNearestAway: "ToNearestAway",
+	ToZero:        "ToZero",
+	AwayFromZero:  "AwayFromZero",
+	ToNegativeInf: "ToNegativeInf",
+	ToPositiveInf: "ToPositiveInf",
+	Exact:         "Exact",
+}
+
+func (mode RoundingMode) String() string {
+	if int(mode) < len(roundingModeNames) {
+		return roundingModeNames[mode]
+	}
+	return "RoundingMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+// quoRound returns n/d rounded to an integer according to mode, as well as
+// whether or not the division was exact. d must be positive. When mode is
+// Exact, the result is truncated and the caller is expected to check exact.
+func quoRound(n, d *big.Int, mode RoundingMode) (*big.Int, bool) {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q, true
+	}
+
+	// q has been truncated towards zero. Decide whether it should instead be
+	// moved one step away from zero.
+	negative := n.Sign() < 0
+	var away bool
+	switch mode {
+	case ToNearestEven, ToNearestAway:
+		c := new(big.Int).Abs(r)
+		c.Lsh(c, 1)
+		switch c.Cmp(d) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == ToNearestAway || q.Bit(0) == 1
+		}
+	case AwayFromZero:
+		away = true
+	case ToNegativeInf:
+		away = negative
+	case ToPositiveInf:
+		away = !negative
+	}
+	if away {
+		if negative {
+			q.Sub(q, bigOne)
+		} else {
+			q.Add(q, bigOne)
+		}
+	}
+	return q, false
+}
+
+// bigRoundingMode returns the math/big equivalent of mode. Exact, which has no
+// equivalent, is mapped to big.ToNearestEven.
+func bigRoundingMode(mode RoundingMode) big.RoundingMode {
+	if mode == Exact {
+		return big.ToNearestEven
+	}
+	// The remaining modes are declared in the same order as math/big's.
+	return big.RoundingMode(mode)
+}
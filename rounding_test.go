@@ -0,0 +1,62 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestQuoRound(t *testing.T) {
+	type quoRoundTest struct {
+		n, d  int64
+		mode  RoundingMode
+		q     int64
+		exact bool
+	}
+
+	tests := []quoRoundTest{
+		{n: 10, d: 5, mode: ToNearestEven, q: 2, exact: true},
+		{n: -10, d: 5, mode: ToZero, q: -2, exact: true},
+		{n: 25, d: 10, mode: ToNearestEven, q: 2},
+		{n: 35, d: 10, mode: ToNearestEven, q: 4},
+		{n: -25, d: 10, mode: ToNearestEven, q: -2},
+		{n: -35, d: 10, mode: ToNearestEven, q: -4},
+		{n: 25, d: 10, mode: ToNearestAway, q: 3},
+		{n: -25, d: 10, mode: ToNearestAway, q: -3},
+		{n: 24, d: 10, mode: ToNearestAway, q: 2},
+		{n: 26, d: 10, mode: ToNearestEven, q: 3},
+		{n: 29, d: 10, mode: ToZero, q: 2},
+		{n: -29, d: 10, mode: ToZero, q: -2},
+		{n: 21, d: 10, mode: AwayFromZero, q: 3},
+		{n: -21, d: 10, mode: AwayFromZero, q: -3},
+		{n: 21, d: 10, mode: ToNegativeInf, q: 2},
+		{n: -21, d: 10, mode: ToNegativeInf, q: -3},
+		{n: 21, d: 10, mode: ToPositiveInf, q: 3},
+		{n: -21, d: 10, mode: ToPositiveInf, q: -2},
+		{n: 29, d: 10, mode: Exact, q: 2},
+		{n: 1, d: 3, mode: ToNearestEven, q: 0},
+		{n: 2, d: 3, mode: ToNearestEven, q: 1},
+	}
+
+	for _, test := range tests {
+		q, exact := quoRound(big.NewInt(test.n), big.NewInt(test.d), test.mode)
+		if q.Int64() != test.q || exact != test.exact {
+			t.Errorf("%d/%d (%v): expected %d (exact %t), received %d (exact %t).", test.n, test.d, test.mode, test.q, test.exact, q.Int64(), exact)
+		}
+	}
+}
+
+func TestRoundingModeString(t *testing.T) {
+	if ToNearestEven.String() != "ToNearestEven" {
+		t.Errorf("Expected 'ToNearestEven', received '%s'.", ToNearestEven.String())
+	}
+	if Exact.String() != "Exact" {
+		t.Errorf("Expected 'Exact', received '%s'.", Exact.String())
+	}
+	if RoundingMode(100).String() != "RoundingMode(100)" {
+		t.Errorf("Expected 'RoundingMode(100)', received '%s'.", RoundingMode(100).String())
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+// Scale returns the number of digits after the decimal separator of d. For
+// example, the scale of 1.50 is 2. The scale of NaN and infinities is 0.
+func (d *Decimal) Scale() int {
+	if d.form != finite {
+		return 0
+	}
+	return d.denominatorDigits
+}
+
+// Precision returns the number of significant digits of d: the digits of its
+// unscaled value, without leading zeros. For example, the precision of 1.50 is
+// 3, and the precision of 0.050 is 2. Zero has a precision of 1, and NaN and
+// infinities have a precision of 0.
+func (d *Decimal) Precision() int {
+	if d.form != finite {
+		return 0
+	}
+	return numDigits(d.coefficient())
+}
+
+// Reduce removes trailing zeros after the decimal separator of d, without
+// changing its value, and returns d. For example, 1.500 is reduced to 1.5, and
+// 2.00 to 2. Special values are unchanged.
+func (d *Decimal) Reduce() *Decimal {
+	if d.form != finite {
+		return d
+	}
+	d.denominator, d.denominatorDigits = simplifyNumber(d.denominator, d.denominatorDigits)
+	if d.denominator == 0 {
+		d.denominatorDigits = 0
+	}
+	return d
+}
+
+// Normalize is the same as Reduce. It is named after the equivalent operation
+// of earlier versions of the General Decimal Arithmetic specification.
+func (d *Decimal) Normalize() *Decimal {
+	return d.Reduce()
+}
@@ -0,0 +1,77 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestScaleAndPrecision(t *testing.T) {
+	tests := []struct {
+		input            string
+		scale, precision int
+		reduced          string
+	}{
+		{input: "1.50", scale: 2, precision: 3, reduced: "1.5"},
+		{input: "-1.500", scale: 3, precision: 4, reduced: "-1.5"},
+		{input: "0.050", scale: 3, precision: 2, reduced: "0.05"},
+		{input: "2.00", scale: 2, precision: 3, reduced: "2.0"},
+		{input: "120", scale: 0, precision: 3, reduced: "120.0"},
+		{input: "0.000", scale: 3, precision: 1, reduced: "0.0"},
+		{input: "18446744073709551615.18446744073709551615", scale: 20, precision: 40, reduced: "18446744073709551615.18446744073709551615"},
+		{input: "Inf", scale: 0, precision: 0, reduced: "Infinity"},
+		{input: "NaN", scale: 0, precision: 0, reduced: "NaN"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		if d.Scale() != test.scale {
+			t.Errorf("'%s': Expected scale %d, received %d.", test.input, test.scale, d.Scale())
+		}
+		if d.Precision() != test.precision {
+			t.Errorf("'%s': Expected precision %d, received %d.", test.input, test.precision, d.Precision())
+		}
+		if r := d.Reduce(); r != d || d.String() != test.reduced {
+			t.Errorf("'%s': Expected '%s', received '%s'.", test.input, test.reduced, d.String())
+		}
+		if d.Normalize().String() != test.reduced {
+			t.Errorf("'%s': Expected '%s' after normalizing twice, received '%s'.", test.input, test.reduced, d.String())
+		}
+	}
+
+	d, _ := ParseDecimal("2.00")
+	if d.Reduce().Scale() != 0 {
+		t.Errorf("Expected a reduced integer to have a scale of 0, received %d.", d.Scale())
+	}
+}
+
+func TestArithmeticKeepsScale(t *testing.T) {
+	tests := []struct {
+		input1, op, input2, output string
+	}{
+		{"1.50", "+", "2.5", "4.00"},
+		{"10.00", "-", "0.50", "9.50"},
+		{"1.50", "*", "2.0", "3.000"},
+		{"19.99", "-", "19.99", "0.00"},
+	}
+
+	for _, test := range tests {
+		d1, _ := ParseDecimal(test.input1)
+		d2, _ := ParseDecimal(test.input2)
+		var err error
+		switch test.op {
+		case "+":
+			err = d1.Add(d2)
+		case "-":
+			err = d1.Sub(d2)
+		case "*":
+			err = d1.Mul(d2)
+		}
+		if err != nil || d1.String() != test.output {
+			t.Errorf("'%s %s %s': Expected '%s', received '%s' (error '%v').", test.input1, test.op, test.input2, test.output, d1.String(), err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+// Neg negates d. Zero is never negative, so negating it leaves it unchanged.
+// The sign of NaN is negated as with any other value. ErrNotValid is returned
+// if d is not Valid.
+func (d *Decimal) Neg() error {
+	if !d.Valid {
+		return ErrNotValid
+	}
+	d.Negative = !d.Negative && !d.isZero()
+	return nil
+}
+
+// Abs sets d to its absolute value. ErrNotValid is returned if d is not Valid.
+func (d *Decimal) Abs() error {
+	if !d.Valid {
+		return ErrNotValid
+	}
+	d.Negative = false
+	return nil
+}
+
+// Sign returns -1 if d is negative, 0 if d is zero, and +1 if d is positive.
+// Infinities have the sign of their value. Sign returns 0 if d is NaN or not
+// Valid.
+func (d *Decimal) Sign() int {
+	switch {
+	case !d.Valid || d.IsNaN() || d.isZero():
+		return 0
+	case d.Negative:
+		return -1
+	}
+	return 1
+}
+
+// IsZero reports whether d is Valid and zero, whatever its scale.
+func (d *Decimal) IsZero() bool {
+	return d.Valid && d.isZero()
+}
+
+// IsInteger reports whether d is Valid, finite and has no fractional part,
+// whatever its scale. For example, 2.00 is an integer.
+func (d *Decimal) IsInteger() bool {
+	return d.Valid && d.form == finite && d.denominator == 0
+}
+
+// IsPositive reports whether d is greater than zero, including positive
+// infinity. It is false for NaN, and if d is not Valid.
+func (d *Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+// IsNegative reports whether d is less than zero, including negative infinity.
+// It is false for NaN, and if d is not Valid.
+func (d *Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
@@ -0,0 +1,62 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "testing"
+
+func TestSignPredicates(t *testing.T) {
+	tests := []struct {
+		input                   string
+		sign                    int
+		zero, integer, pos, neg bool
+		negated, absolute       string
+	}{
+		{input: "1.5", sign: 1, pos: true, negated: "-1.5", absolute: "1.5"},
+		{input: "-1.5", sign: -1, neg: true, negated: "1.5", absolute: "1.5"},
+		{input: "-2.00", sign: -1, integer: true, neg: true, negated: "2.00", absolute: "2.00"},
+		{input: "18446744073709551615", sign: 1, integer: true, pos: true, negated: "-18446744073709551615.0", absolute: "18446744073709551615.0"},
+		{input: "0", sign: 0, zero: true, integer: true, negated: "0.0", absolute: "0.0"},
+		{input: "0.000", sign: 0, zero: true, integer: true, negated: "0.000", absolute: "0.000"},
+		{input: "0.0000000000000000001", sign: 1, pos: true, negated: "-0.0000000000000000001", absolute: "0.0000000000000000001"},
+		{input: "-Inf", sign: -1, neg: true, negated: "Infinity", absolute: "Infinity"},
+		{input: "Inf", sign: 1, pos: true, negated: "-Infinity", absolute: "Infinity"},
+		{input: "-NaN", sign: 0, negated: "NaN", absolute: "NaN"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		if d.Sign() != test.sign {
+			t.Errorf("'%s': Expected sign %d, received %d.", test.input, test.sign, d.Sign())
+		}
+		if d.IsZero() != test.zero || d.IsInteger() != test.integer {
+			t.Errorf("'%s': Expected IsZero %v and IsInteger %v.", test.input, test.zero, test.integer)
+		}
+		if d.IsPositive() != test.pos || d.IsNegative() != test.neg {
+			t.Errorf("'%s': Expected IsPositive %v and IsNegative %v.", test.input, test.pos, test.neg)
+		}
+
+		negated := *d
+		if err := negated.Neg(); err != nil || negated.String() != test.negated {
+			t.Errorf("'%s': Expected negation '%s', received '%s' (error '%v').", test.input, test.negated, negated.String(), err)
+		}
+		if negated.IsZero() && negated.Negative {
+			t.Errorf("'%s': Expected a zero that is not Negative.", test.input)
+		}
+		if err := d.Abs(); err != nil || d.String() != test.absolute {
+			t.Errorf("'%s': Expected absolute value '%s', received '%s' (error '%v').", test.input, test.absolute, d.String(), err)
+		}
+	}
+
+	invalid := &Decimal{}
+	if invalid.Neg() != ErrNotValid || invalid.Abs() != ErrNotValid {
+		t.Errorf("Expected ErrNotValid from Neg and Abs.")
+	}
+	if invalid.Sign() != 0 || invalid.IsZero() || invalid.IsInteger() || invalid.IsPositive() || invalid.IsNegative() {
+		t.Errorf("Expected predicates to be false for an invalid value.")
+	}
+}
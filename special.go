@@ -0,0 +1,203 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "strings"
+
+// form distinguishes finite values from the special values of the General
+// Decimal Arithmetic specification. The zero form is finite.
+type form byte
+
+const (
+	finite   form = iota
+	infinite      // an infinity, signed by Negative
+	qnan          // a quiet NaN
+	snan          // a signaling NaN
+)
+
+// NaN returns a new Decimal holding a quiet NaN, which propagates through
+// arithmetic operations.
+func NaN() *Decimal {
+	return &Decimal{Valid: true, form: qnan}
+}
+
+// SignalingNaN returns a new Decimal holding a signaling NaN. An arithmetic
+// operation on a signaling NaN is an invalid operation, with a quiet NaN as its
+// result.
+func SignalingNaN() *Decimal {
+	return &Decimal{Valid: true, form: snan}
+}
+
+// Inf returns a new Decimal holding positive infinity if sign >= 0, and
+// negative infinity if sign < 0.
+func Inf(sign int) *Decimal {
+	return &Decimal{Valid: true, Negative: sign < 0, form: infinite}
+}
+
+// IsNaN reports whether d is a quiet or signaling NaN.
+func (d *Decimal) IsNaN() bool {
+	return d.Valid && (d.form == qnan || d.form == snan)
+}
+
+// IsSignalingNaN reports whether d is a signaling NaN.
+func (d *Decimal) IsSignalingNaN() bool {
+	return d.Valid && d.form == snan
+}
+
+// IsInf reports whether d is an infinity, according to sign. If sign > 0,
+// IsInf reports whether d is positive infinity. If sign < 0, IsInf reports
+// whether d is negative infinity. If sign == 0, IsInf reports whether d is
+// either infinity.
+func (d *Decimal) IsInf(sign int) bool {
+	return d.Valid && d.form == infinite && (sign >= 0 && !d.Negative || sign <= 0 && d.Negative)
+}
+
+// IsFinite reports whether d is Valid, and neither NaN nor an infinity.
+func (d *Decimal) IsFinite() bool {
+	return d.Valid && d.form == finite
+}
+
+// checkFinite returns ErrNotValid if d is not Valid, and ErrNotFinite if d is
+// NaN or an infinity.
+func (d *Decimal) checkFinite() error {
+	if !d.Valid {
+		return ErrNotValid
+	}
+	if d.form != finite {
+		return ErrNotFinite
+	}
+	return nil
+}
+
+// allFinite returns the error from checkFinite for the first of ds that is
+// not finite, if any.
+func allFinite(ds ...*Decimal) error {
+	for _, d := range ds {
+		if err := d.checkFinite(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSpecial parses the special values accepted by ParseDecimal: an optional
+// sign followed by "NaN", "sNaN", "Inf" or "Infinity", in any case.
+func parseSpecial(s string) (*Decimal, bool) {
+	d := &Decimal{Valid: true}
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		d.Negative = s[0] == '-'
+		s = s[1:]
+	}
+	switch strings.ToLower(s) {
+	case "nan":
+		d.form = qnan
+	case "snan":
+		d.form = snan
+	case "inf", "infinity":
+		d.form = infinite
+	default:
+		return nil, false
+	}
+	return d, true
+}
+
+// specialString returns the string representation of a special value.
+func (d *Decimal) specialString() string {
+	var s string
+	switch d.form {
+	case infinite:
+		s = "Infinity"
+	case qnan:
+		s = "NaN"
+	case snan:
+		s = "sNaN"
+	}
+	if d.Negative {
+		return "-" + s
+	}
+	return s
+}
+
+// specialRank orders d among special values for Cmp: NaN, then negative
+// infinity, then finite values, then positive infinity.
+func (d *Decimal) specialRank() int {
+	switch {
+	case d.form == qnan || d.form == snan:
+		return 0
+	case d.form == infinite && d.Negative:
+		return 1
+	case d.form == infinite:
+		return 3
+	}
+	return 2
+}
+
+// specialResult returns the result of d1 op d2, where op is one of "+", "-",
+// "*" or "/", if either d1 or d2 is a special value. The conditions signalled
+// are also returned. ok is false if both d1 and d2 are finite.
+//
+// As in IEEE 754, a NaN operand gives a NaN result, with the first of d1 and d2
+// that is a NaN taking precedence. A signaling NaN operand, and operations such
+// as infinity minus infinity, are invalid and give a quiet NaN.
+func specialResult(op string, d1, d2 *Decimal) (result Decimal, cond Condition, ok bool) {
+	if d1.form == finite && d2.form == finite {
+		return result, 0, false
+	}
+	if result, cond, ok = propagateNaN(d1, d2); ok {
+		return result, cond, true
+	}
+
+	nan := Decimal{Valid: true, form: qnan}
+	switch op {
+	case "+", "-":
+		negative2 := d2.Negative != (op == "-")
+		if d1.form == infinite && d2.form == infinite && d1.Negative != negative2 {
+			return nan, InvalidOperation, true
+		}
+		if d1.form == infinite {
+			return *d1, 0, true
+		}
+		return Decimal{Valid: true, Negative: negative2, form: infinite}, 0, true
+	case "*":
+		if d1.isZero() || d2.isZero() {
+			return nan, InvalidOperation, true
+		}
+		return Decimal{Valid: true, Negative: d1.Negative != d2.Negative, form: infinite}, 0, true
+	default:
+		if d1.form == infinite && d2.form == infinite {
+			return nan, InvalidOperation, true
+		}
+		if d1.form == infinite {
+			return Decimal{Valid: true, Negative: d1.Negative != d2.Negative, form: infinite}, 0, true
+		}
+		// A finite value divided by infinity.
+		return Decimal{Valid: true}, 0, true
+	}
+}
+
+// propagateNaN returns the result of an operation on d1 and d2 if either is
+// NaN: the first signaling NaN, made quiet, or otherwise the first quiet NaN.
+// InvalidOperation is signalled for a signaling NaN. ok is false if neither is
+// NaN.
+func propagateNaN(d1, d2 *Decimal) (result Decimal, cond Condition, ok bool) {
+	for _, f := range []form{snan, qnan} {
+		for _, d := range []*Decimal{d1, d2} {
+			if d.form == f {
+				result = *d
+				result.form = qnan
+				if f == snan {
+					cond = InvalidOperation
+				}
+				return result, cond, true
+			}
+		}
+	}
+	return result, 0, false
+}
+
+// isZero reports whether d is a finite zero.
+func (d *Decimal) isZero() bool {
+	return d.form == finite && d.numerator == 0 && d.denominator == 0
+}
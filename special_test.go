@@ -0,0 +1,166 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParseSpecial(t *testing.T) {
+	tests := map[string]string{
+		"NaN":       "NaN",
+		"nan":       "NaN",
+		"-NaN":      "-NaN",
+		"sNaN":      "sNaN",
+		"SNAN":      "sNaN",
+		"Inf":       "Infinity",
+		"+inf":      "Infinity",
+		"-Inf":      "-Infinity",
+		"Infinity":  "Infinity",
+		"-infinity": "-Infinity",
+	}
+
+	for input, output := range tests {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Errorf("'%s': Expected success, received error '%v'.", input, err)
+			continue
+		}
+		if d.String() != output || d.FormattedString() != output {
+			t.Errorf("'%s': Expected '%s', received '%s' and '%s'.", input, output, d.String(), d.FormattedString())
+		}
+	}
+
+	for _, input := range []string{"NaN1", "Infinit", "--Inf", "+", "qNaN"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("'%s': Expected failure.", input)
+		}
+	}
+}
+
+func TestSpecialPredicates(t *testing.T) {
+	tests := []struct {
+		d                                  *Decimal
+		nan, signaling, inf, pos, neg, fin bool
+	}{
+		{d: NaN(), nan: true},
+		{d: SignalingNaN(), nan: true, signaling: true},
+		{d: Inf(1), inf: true, pos: true},
+		{d: Inf(-1), inf: true, neg: true},
+		{d: NewFromInt64(-1), fin: true},
+		{d: &Decimal{}},
+	}
+
+	for _, test := range tests {
+		s := test.d.String()
+		if test.d.IsNaN() != test.nan || test.d.IsSignalingNaN() != test.signaling || test.d.IsFinite() != test.fin {
+			t.Errorf("'%s': Expected IsNaN %v, IsSignalingNaN %v and IsFinite %v.", s, test.nan, test.signaling, test.fin)
+		}
+		if test.d.IsInf(0) != test.inf || test.d.IsInf(1) != test.pos || test.d.IsInf(-1) != test.neg {
+			t.Errorf("'%s': Expected IsInf %v, %v and %v.", s, test.inf, test.pos, test.neg)
+		}
+	}
+}
+
+func TestSpecialCmp(t *testing.T) {
+	// In ascending order, with equal values adjacent.
+	values := []string{"NaN", "sNaN", "-Inf", "-18446744073709551615.0", "-1.5", "0", "0.0000000000000000001", "18446744073709551615.0", "Inf", "Infinity"}
+	rank := []int{0, 0, 1, 2, 3, 4, 5, 6, 7, 7}
+
+	for i, s1 := range values {
+		d1, _ := ParseDecimal(s1)
+		for j, s2 := range values {
+			d2, _ := ParseDecimal(s2)
+			expected := 0
+			if rank[i] < rank[j] {
+				expected = -1
+			} else if rank[i] > rank[j] {
+				expected = 1
+			}
+			if r := d1.Cmp(d2); r != expected {
+				t.Errorf("'%s' and '%s': Expected %d, received %d.", s1, s2, expected, r)
+			}
+		}
+	}
+}
+
+func TestSpecialOperations(t *testing.T) {
+	tests := []operationTest{
+		{description: "NaN propagates", input1: "1.5", input2: "NaN", result: testResult{output: "NaN"}},
+		{description: "Sign of the first NaN is kept", input1: "-NaN", input2: "NaN", result: testResult{negative: true, output: "-NaN"}},
+		{description: "Signaling NaN becomes quiet", input1: "NaN", input2: "sNaN", result: testResult{output: "NaN"}},
+		{description: "Infinity absorbs finite values", input1: "-18446744073709551615.0", input2: "Inf", result: testResult{output: "Infinity"}},
+		{description: "Infinities of the same sign", input1: "-Inf", input2: "-Inf", result: testResult{negative: true, output: "-Infinity"}},
+		{description: "Infinities of opposite sign", input1: "Inf", input2: "-Inf", result: testResult{output: "NaN"}},
+	}
+	testOperation(t, tests, "+")
+
+	tests = []operationTest{
+		{description: "Infinity minus infinity", input1: "Inf", input2: "Inf", result: testResult{output: "NaN"}},
+		{description: "Finite minus infinity", input1: "1", input2: "Inf", result: testResult{negative: true, output: "-Infinity"}},
+		{description: "Infinity minus negative infinity", input1: "Inf", input2: "-Inf", result: testResult{output: "Infinity"}},
+	}
+	testOperation(t, tests, "-")
+
+	tests = []operationTest{
+		{description: "Signs multiply", input1: "-2", input2: "Inf", result: testResult{negative: true, output: "-Infinity"}},
+		{description: "Infinity times zero", input1: "0.00", input2: "-Inf", result: testResult{output: "NaN"}},
+		{description: "NaN times zero", input1: "0", input2: "NaN", result: testResult{output: "NaN"}},
+	}
+	testOperation(t, tests, "*")
+
+	quoTests := map[[2]string]string{
+		{"Inf", "-2"}:  "-Infinity",
+		{"Inf", "0"}:   "Infinity",
+		{"-5", "Inf"}:  "0.0",
+		{"Inf", "Inf"}: "NaN",
+		{"NaN", "0"}:   "NaN",
+	}
+	for inputs, output := range quoTests {
+		d1, _ := ParseDecimal(inputs[0])
+		d2, _ := ParseDecimal(inputs[1])
+		if err := d1.Quo(d2, 2, ToNearestEven); err != nil || d1.String() != output {
+			t.Errorf("'%s / %s': Expected '%s', received '%s' (error '%v').", inputs[0], inputs[1], output, d1.String(), err)
+		}
+	}
+}
+
+func TestSpecialConversions(t *testing.T) {
+	for _, d := range []*Decimal{NaN(), Inf(1), Inf(-1)} {
+		if _, err := d.Int64(); err != ErrNotFinite {
+			t.Errorf("'%s': Expected ErrNotFinite from Int64, received '%v'.", d.String(), err)
+		}
+		if _, err := d.ToMinorUnits(2, ToNearestEven); err != ErrNotFinite {
+			t.Errorf("'%s': Expected ErrNotFinite from ToMinorUnits, received '%v'.", d.String(), err)
+		}
+		if _, err := d.MarshalCBOR(); err == nil || err.(*NumError).Err != ErrNotFinite {
+			t.Errorf("'%s': Expected ErrNotFinite from MarshalCBOR, received '%v'.", d.String(), err)
+		}
+		if d.Rat() != nil {
+			t.Errorf("'%s': Expected a nil Rat.", d.String())
+		}
+	}
+
+	if f, exact := Inf(-1).Float64(); !math.IsInf(f, -1) || !exact {
+		t.Errorf("Expected -Inf (exact), received %g (%v).", f, exact)
+	}
+	if f, exact := NaN().Float64(); !math.IsNaN(f) || exact {
+		t.Errorf("Expected NaN (inexact), received %g (%v).", f, exact)
+	}
+	if f, _ := Inf(1).BigFloat(64); f == nil || !f.IsInf() || f.Sign() != 1 {
+		t.Errorf("Expected +Inf, received '%v'.", f)
+	}
+	if f, _ := NaN().BigFloat(64); f != nil {
+		t.Errorf("Expected nil, received '%v'.", f)
+	}
+
+	// Setting a special value to a finite one clears it.
+	d := Inf(1)
+	if _, err := d.SetRat(big.NewRat(1, 4), 2, ToNearestEven); err != nil || d.String() != "0.25" || !d.IsFinite() {
+		t.Errorf("Expected '0.25', received '%s' (error '%v').", d.String(), err)
+	}
+}
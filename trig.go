@@ -0,0 +1,379 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import "math/big"
+
+// The trigonometric functions are correctly rounded in the same way as Exp and
+// the logarithms. Arguments are in radians.
+
+// Sin sets d to the sine of d, correctly rounded according to ctx. The sine of
+// 0 is exactly 0, and every other finite result is inexact. The sine of an
+// infinity is an invalid operation with a NaN result. d is unchanged if an
+// error is returned.
+func (d *Decimal) Sin(ctx *Context) error {
+	return d.trig("Sin", ctx, sinOf)
+}
+
+// Cos sets d to the cosine of d, correctly rounded according to ctx, with
+// special cases as for Sin, except that the cosine of 0 is exactly 1.
+func (d *Decimal) Cos(ctx *Context) error {
+	return d.trig("Cos", ctx, cosOf)
+}
+
+// Tan sets d to the tangent of d, correctly rounded according to ctx, with
+// special cases as for Sin.
+func (d *Decimal) Tan(ctx *Context) error {
+	return d.trig("Tan", ctx, tanOf)
+}
+
+// SinRat returns the sine of x as a *big.Rat, correctly rounded to the
+// precision of ctx. Like PiRat, the result is not limited to what a Decimal
+// can hold, and ctx must have a precision. The sine of 0 is exactly 0.
+func SinRat(x *big.Rat, ctx *Context) (*big.Rat, error) {
+	return ctx.trigRat("SinRat", x, sinOf)
+}
+
+// CosRat returns the cosine of x as a *big.Rat, correctly rounded to the
+// precision of ctx, as for SinRat, except that the cosine of 0 is exactly 1.
+func CosRat(x *big.Rat, ctx *Context) (*big.Rat, error) {
+	return ctx.trigRat("CosRat", x, cosOf)
+}
+
+// TanRat returns the tangent of x as a *big.Rat, correctly rounded to the
+// precision of ctx, as for SinRat.
+func TanRat(x *big.Rat, ctx *Context) (*big.Rat, error) {
+	return ctx.trigRat("TanRat", x, tanOf)
+}
+
+// trigSelector selects the result of a trigonometric function from the sine
+// and cosine of its argument reduced to the range [-pi/4, pi/4], and the
+// quadrant that the argument was reduced from.
+type trigSelector func(sin, cos *big.Float, quadrant uint) *big.Float
+
+func sinOf(sin, cos *big.Float, quadrant uint) *big.Float {
+	switch quadrant {
+	case 0:
+		return sin
+	case 1:
+		return cos
+	case 2:
+		return sin.Neg(sin)
+	}
+	return cos.Neg(cos)
+}
+
+func cosOf(sin, cos *big.Float, quadrant uint) *big.Float {
+	switch quadrant {
+	case 0:
+		return cos
+	case 1:
+		return sin.Neg(sin)
+	case 2:
+		return cos.Neg(cos)
+	}
+	return sin
+}
+
+func tanOf(sin, cos *big.Float, quadrant uint) *big.Float {
+	if quadrant%2 == 0 {
+		return sin.Quo(sin, cos)
+	}
+	return cos.Quo(cos.Neg(cos), sin)
+}
+
+// trigApprox returns an approxFunc for the trigonometric function of x that f
+// selects.
+func trigApprox(x *big.Rat, f trigSelector) approxFunc {
+	return func(prec uint) *big.Float {
+		r, quadrant := reduceHalfPi(x, prec+2)
+		sin, cos := bigSinCos(r)
+		return f(sin, cos, quadrant)
+	}
+}
+
+// trigRat implements SinRat, CosRat and TanRat.
+func (ctx *Context) trigRat(fnName string, x *big.Rat, f trigSelector) (*big.Rat, error) {
+	if x.Sign() == 0 {
+		if fnName == "CosRat" {
+			return big.NewRat(1, 1), nil
+		}
+		return new(big.Rat), nil
+	}
+	return ctx.approxRat(fnName, x.RatString(), trigApprox(x, f))
+}
+
+// trig implements Sin, Cos and Tan, where f selects the result.
+func (d *Decimal) trig(fnName string, ctx *Context, f trigSelector) error {
+	if err := ctx.checkValid(d, d); err != nil {
+		return err
+	}
+	num := d.String()
+	if result, cond, ok := propagateNaN(d, d); ok {
+		return ctx.setResult(fnName, num, d, result, cond)
+	}
+	if d.form == infinite {
+		return ctx.setResult(fnName, num, d, Decimal{Valid: true, form: qnan}, InvalidOperation)
+	}
+	if d.isZero() {
+		result := Decimal{Valid: true}
+		if fnName == "Cos" {
+			result.numerator = 1
+		}
+		return ctx.setResult(fnName, num, d, result, 0)
+	}
+
+	return ctx.setApprox(fnName, num, d, trigApprox(d.rat(), f), nil)
+}
+
+// Atan sets d to the arctangent of d, in the range [-pi/2, pi/2], correctly
+// rounded according to ctx. The arctangent of 0 is exactly 0, and every other
+// result is inexact, including the arctangents of the infinities, which are
+// -pi/2 and pi/2. d is unchanged if an error is returned.
+func (d *Decimal) Atan(ctx *Context) error {
+	if err := ctx.checkValid(d, d); err != nil {
+		return err
+	}
+	num := d.String()
+	if result, cond, ok := propagateNaN(d, d); ok {
+		return ctx.setResult("Atan", num, d, result, cond)
+	}
+	if d.isZero() {
+		return ctx.setResult("Atan", num, d, Decimal{Valid: true}, 0)
+	}
+	if d.form == infinite {
+		return ctx.setPiMultiple("Atan", num, d, big.NewRat(int64(d.Sign()), 2))
+	}
+
+	return ctx.setApprox("Atan", num, d, atanApprox(d.rat()), nil)
+}
+
+// AtanRat returns the arctangent of x as a *big.Rat, in the range
+// [-pi/2, pi/2], correctly rounded to the precision of ctx, as for SinRat. The
+// arctangent of 0 is exactly 0.
+func AtanRat(x *big.Rat, ctx *Context) (*big.Rat, error) {
+	if x.Sign() == 0 {
+		return new(big.Rat), nil
+	}
+	return ctx.approxRat("AtanRat", x.RatString(), atanApprox(x))
+}
+
+// atanApprox returns an approxFunc for the arctangent of x.
+func atanApprox(x *big.Rat) approxFunc {
+	return func(prec uint) *big.Float {
+		return bigAtan(new(big.Float).SetPrec(prec+2*guardBits).SetRat(x), prec)
+	}
+}
+
+// Atan2 sets d to the angle, in the range [-pi, pi], between the positive x
+// axis and the point (x, d), correctly rounded according to ctx. As with
+// math.Atan2, infinite coordinates give multiples of pi/4, and if d is 0 the
+// result is 0 for x >= 0 and pi for x < 0. A result of 0 is exact, and every
+// other result is inexact. d is unchanged if an error is returned.
+func (d *Decimal) Atan2(x *Decimal, ctx *Context) error {
+	if err := ctx.checkValid(d, x); err != nil {
+		return err
+	}
+	num := d.String() + ", " + x.String()
+	if result, cond, ok := propagateNaN(d, x); ok {
+		return ctx.setResult("Atan2", num, d, result, cond)
+	}
+
+	// The result is a multiple of pi if either coordinate is zero or
+	// infinite.
+	var multiple *big.Rat
+	switch {
+	case d.isZero():
+		multiple = new(big.Rat)
+		if x.Negative {
+			multiple.SetInt64(1)
+		}
+	case x.isZero() || d.form == infinite && x.form == finite:
+		multiple = big.NewRat(1, 2)
+	case d.form == infinite && x.Negative:
+		multiple = big.NewRat(3, 4)
+	case d.form == infinite:
+		multiple = big.NewRat(1, 4)
+	case x.IsInf(1):
+		multiple = new(big.Rat)
+	case x.IsInf(-1):
+		multiple = big.NewRat(1, 1)
+	}
+	if multiple != nil {
+		if d.Negative {
+			multiple.Neg(multiple)
+		}
+		if multiple.Sign() == 0 {
+			return ctx.setResult("Atan2", num, d, Decimal{Valid: true}, 0)
+		}
+		return ctx.setPiMultiple("Atan2", num, d, multiple)
+	}
+
+	return ctx.setApprox("Atan2", num, d, atan2Approx(d.rat(), x.rat()), nil)
+}
+
+// Atan2Rat returns the angle, in the range [-pi, pi], between the positive x
+// axis and the point (x, y) as a *big.Rat, correctly rounded to the precision
+// of ctx, as for SinRat. If y is 0 the result is exactly 0 for x >= 0, and pi
+// for x < 0.
+func Atan2Rat(y, x *big.Rat, ctx *Context) (*big.Rat, error) {
+	num := y.RatString() + ", " + x.RatString()
+	switch {
+	case y.Sign() == 0 && x.Sign() >= 0:
+		return new(big.Rat), nil
+	case y.Sign() == 0:
+		return ctx.approxRat("Atan2Rat", num, piMultipleApprox(big.NewRat(1, 1)))
+	case x.Sign() == 0:
+		return ctx.approxRat("Atan2Rat", num, piMultipleApprox(big.NewRat(int64(y.Sign()), 2)))
+	}
+	return ctx.approxRat("Atan2Rat", num, atan2Approx(y, x))
+}
+
+// atan2Approx returns an approxFunc for the angle of the point (x, y), neither
+// of which is zero: atan(y/x), moved into the correct half of the plane when
+// x < 0. The result is then larger than pi/2 in magnitude, so adding pi loses
+// no precision.
+func atan2Approx(y, x *big.Rat) approxFunc {
+	q := new(big.Rat).Quo(y, x)
+	negative, left := y.Sign() < 0, x.Sign() < 0
+	return func(prec uint) *big.Float {
+		r := bigAtan(new(big.Float).SetPrec(prec+2*guardBits).SetRat(q), prec+2)
+		if left {
+			pi := piConstant.get(prec + 2)
+			if negative {
+				pi.Neg(pi)
+			}
+			r.Add(r, pi)
+		}
+		return r
+	}
+}
+
+// setPiMultiple sets d to m*pi, correctly rounded according to ctx.
+func (ctx *Context) setPiMultiple(fnName, num string, d *Decimal, m *big.Rat) error {
+	return ctx.setApprox(fnName, num, d, piMultipleApprox(m), nil)
+}
+
+// piMultipleApprox returns an approxFunc for m*pi.
+func piMultipleApprox(m *big.Rat) approxFunc {
+	return func(prec uint) *big.Float {
+		pi := piConstant.get(prec + 1)
+		return pi.Mul(pi, new(big.Float).SetPrec(pi.Prec()).SetRat(m))
+	}
+}
+
+// reduceHalfPi returns r and the quadrant k mod 4 such that x = k*pi/2 + r,
+// with |r| <= pi/4. r has a relative error of less than 2**-prec, which needs
+// more precision the closer x is to a multiple of pi/2.
+func reduceHalfPi(x *big.Rat, prec uint) (*big.Float, uint) {
+	wp := prec + guardBits
+	if exp := new(big.Float).SetRat(x).MantExp(nil); exp > 0 {
+		wp += uint(exp)
+	}
+	for {
+		halfPi := piConstant.get(wp)
+		halfPi.SetMantExp(halfPi, -1)
+		xf := new(big.Float).SetPrec(wp).SetRat(x)
+
+		// k = round(x / (pi/2))
+		q := new(big.Float).SetPrec(wp).Quo(xf, halfPi)
+		q.Add(q, big.NewFloat(0.5*float64(q.Sign())))
+		k, _ := q.Int(nil)
+
+		r := new(big.Float).SetPrec(wp).Mul(halfPi, new(big.Float).SetPrec(wp).SetInt(k))
+		r.Sub(xf, r)
+
+		// The absolute error in r is a few units in the last place of x, so
+		// its relative error grows with the bits lost to cancellation.
+		if r.Sign() == 0 {
+			wp *= 2
+			continue
+		}
+		lost := uint(0)
+		if l := xf.MantExp(nil) - r.MantExp(nil); l > 0 {
+			lost = uint(l)
+		}
+		if prec+lost+guardBits/2 <= wp {
+			quadrant := new(big.Int).And(k, big.NewInt(3))
+			return r, uint(quadrant.Uint64())
+		}
+		wp += lost + guardBits
+	}
+}
+
+// bigSinCos returns the sine and cosine of r, for |r| <= pi/4, computed with
+// the precision of r using their Taylor series.
+func bigSinCos(r *big.Float) (*big.Float, *big.Float) {
+	prec := r.Prec()
+	r2 := new(big.Float).SetPrec(prec).Mul(r, r)
+	r2.Neg(r2)
+	series := func(term *big.Float, n int64) *big.Float {
+		sum := new(big.Float).SetPrec(prec).Set(term)
+		for ; ; n += 2 {
+			term.Mul(term, r2)
+			term.Quo(term, newFloat(prec, n*(n+1)))
+			if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec)-1 {
+				return sum
+			}
+			sum.Add(sum, term)
+		}
+	}
+	return series(new(big.Float).SetPrec(prec).Set(r), 2), series(newFloat(prec, 1), 1)
+}
+
+// bigAtanSeries returns atan(z) for |z| <= 1/5, computed with the precision of
+// z using its Taylor series.
+func bigAtanSeries(z *big.Float) *big.Float {
+	prec := z.Prec()
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	if z.Sign() == 0 {
+		return sum
+	}
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	z2.Neg(z2)
+	power := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, z2)
+		term.Quo(power, newFloat(prec, k))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec)-1 {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// bigAtan returns atan(x), with a relative error of less than 2**-prec.
+func bigAtan(x *big.Float, prec uint) *big.Float {
+	const halvings = 3
+	wp := prec + guardBits + halvings
+	z := new(big.Float).SetPrec(wp).Abs(x)
+
+	// atan(z) = pi/2 - atan(1/z), which is at least pi/4 for z > 1.
+	inverted := z.Cmp(newFloat(wp, 1)) > 0
+	if inverted {
+		z.Quo(newFloat(wp, 1), z)
+	}
+
+	// atan(z) = 2*atan(z / (1 + sqrt(1 + z**2))), which brings z below 1/5.
+	one := newFloat(wp, 1)
+	for i := 0; i < halvings; i++ {
+		s := new(big.Float).SetPrec(wp).Mul(z, z)
+		s.Sqrt(s.Add(s, one))
+		z.Quo(z, s.Add(s, one))
+	}
+	r := bigAtanSeries(z)
+	r.SetMantExp(r, halvings)
+
+	if inverted {
+		halfPi := piConstant.get(wp)
+		halfPi.SetMantExp(halfPi, -1)
+		r.Sub(halfPi, r)
+	}
+	if x.Sign() < 0 {
+		r.Neg(r)
+	}
+	return r
+}
@@ -0,0 +1,162 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTrigonometry(t *testing.T) {
+	basic := Context{Precision: 16}
+
+	tests := []struct {
+		ctx        Context
+		fn         string
+		input, x   string
+		output     string
+		flags, err Condition
+	}{
+		{ctx: basic, fn: "Sin", input: "1", output: "0.8414709848078965", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Sin", input: "-2", output: "-0.9092974268256816954", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Sin", input: "100000", output: "0.03574879797201651", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Sin", input: "355", output: "-0.00003014435335948845", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 5, Rounding: ToZero}, fn: "Sin", input: "0.0000001", output: "0.000000099999", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Sin", input: "0.00", output: "0.0"},
+		{ctx: basic, fn: "Sin", input: "Inf", output: "NaN", flags: InvalidOperation},
+		{ctx: DefaultContext, fn: "Sin", input: "-Inf", flags: InvalidOperation, err: InvalidOperation},
+
+		{ctx: basic, fn: "Cos", input: "1", output: "0.5403023058681397", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Cos", input: "355", output: "-0.9999999995456589802", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Cos", input: "1.5707963267948966192", output: "0.00000000000000000003132169163975144", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 20, Rounding: ToZero}, fn: "Cos", input: "0.0000001", output: "0.9999999999999950000", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Cos", input: "0", output: "1.0"},
+
+		{ctx: Context{}, fn: "Tan", input: "1", output: "1.5574077246549022305", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Tan", input: "-2", output: "2.185039863261519", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Tan", input: "1.5707963267948966192", output: "Infinity", flags: Overflow | Inexact | Rounded},
+
+		{ctx: Context{}, fn: "Atan", input: "1", output: "0.7853981633974483096", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Atan", input: "-2", output: "-1.107148717794091", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Atan", input: "100000", output: "1.5707863267948969526", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Atan", input: "-Inf", output: "-1.570796326794897", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Atan", input: "0", output: "0.0"},
+
+		{ctx: basic, fn: "Atan2", input: "1", x: "-1", output: "2.356194490192345", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Atan2", input: "-1", x: "-1", output: "-2.356194490192345", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "Atan2", input: "-0.5", x: "-2", output: "-2.8966139904629290843", flags: Clamped | Inexact | Rounded},
+		{ctx: basic, fn: "Atan2", input: "2", x: "0", output: "1.570796326794897", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Atan2", input: "0", x: "-3", output: "3.141592653589793", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Atan2", input: "0", x: "3", output: "0.0"},
+		{ctx: basic, fn: "Atan2", input: "0", x: "0", output: "0.0"},
+		{ctx: basic, fn: "Atan2", input: "Inf", x: "-Inf", output: "2.356194490192345", flags: Inexact | Rounded},
+		{ctx: basic, fn: "Atan2", input: "-1", x: "Inf", output: "0.0"},
+		{ctx: basic, fn: "Atan2", input: "1", x: "sNaN", output: "NaN", flags: InvalidOperation},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Fatalf("'%s': Expected success, received error '%v'.", test.input, err)
+		}
+		before := *d
+
+		ctx := test.ctx
+		switch test.fn {
+		case "Sin":
+			err = d.Sin(&ctx)
+		case "Cos":
+			err = d.Cos(&ctx)
+		case "Tan":
+			err = d.Tan(&ctx)
+		case "Atan":
+			err = d.Atan(&ctx)
+		case "Atan2":
+			x, _ := ParseDecimal(test.x)
+			err = d.Atan2(x, &ctx)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("%s('%s'): Expected flags '%v', received '%v'.", test.fn, test.input, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err {
+				t.Errorf("%s('%s'): Expected error '%v', received '%v'.", test.fn, test.input, test.err, err)
+			}
+			if *d != before {
+				t.Errorf("%s('%s'): Expected the value to be unchanged on error, received '%s'.", test.fn, test.input, d.String())
+			}
+			continue
+		}
+		if err != nil || d.String() != test.output {
+			t.Errorf("%s('%s'): Expected '%s', received '%s' (error '%v').", test.fn, test.input, test.output, d.String(), err)
+		}
+	}
+
+	ctx := DefaultContext
+	if err := (&Decimal{}).Sin(&ctx); err != ErrNotValid {
+		t.Errorf("Expected ErrNotValid, received '%v'.", err)
+	}
+}
+
+func TestTrigonometryRat(t *testing.T) {
+	digits30 := Context{Precision: 30}
+
+	tests := []struct {
+		ctx      Context
+		fn       string
+		input, x string
+		output   string
+		flags    Condition
+		err      Condition
+	}{
+		{ctx: digits30, fn: "SinRat", input: "1", output: "0.841470984807896506652502321630", flags: Inexact | Rounded},
+		{ctx: digits30, fn: "SinRat", input: "0", output: "0"},
+		{ctx: digits30, fn: "CosRat", input: "1", output: "0.540302305868139717400936607443", flags: Inexact | Rounded},
+		{ctx: digits30, fn: "CosRat", input: "0", output: "1"},
+		{ctx: digits30, fn: "TanRat", input: "1", output: "1.55740772465490223050697480746", flags: Inexact | Rounded},
+		{ctx: Context{Precision: 30, Emax: -1, ExponentLimits: true}, fn: "TanRat", input: "1", flags: Overflow | Inexact | Rounded, err: Overflow},
+		{ctx: digits30, fn: "AtanRat", input: "1", output: "0.785398163397448309615660845820", flags: Inexact | Rounded},
+		{ctx: digits30, fn: "AtanRat", input: "0", output: "0"},
+		{ctx: digits30, fn: "Atan2Rat", input: "-1", x: "-1", output: "-2.35619449019234492884698253746", flags: Inexact | Rounded},
+		{ctx: digits30, fn: "Atan2Rat", input: "0", x: "-3", output: "3.14159265358979323846264338328", flags: Inexact | Rounded},
+		{ctx: digits30, fn: "Atan2Rat", input: "0", x: "3", output: "0"},
+		{ctx: digits30, fn: "Atan2Rat", input: "-2", x: "0", output: "-1.57079632679489661923132169164", flags: Inexact | Rounded},
+		{ctx: Context{}, fn: "SinRat", input: "1", flags: InvalidOperation, err: InvalidOperation},
+	}
+
+	for _, test := range tests {
+		input, _ := new(big.Rat).SetString(test.input)
+		x, _ := new(big.Rat).SetString(test.x)
+
+		ctx := test.ctx
+		var r *big.Rat
+		var err error
+		switch test.fn {
+		case "SinRat":
+			r, err = SinRat(input, &ctx)
+		case "CosRat":
+			r, err = CosRat(input, &ctx)
+		case "TanRat":
+			r, err = TanRat(input, &ctx)
+		case "AtanRat":
+			r, err = AtanRat(input, &ctx)
+		case "Atan2Rat":
+			r, err = Atan2Rat(input, x, &ctx)
+		}
+		if ctx.Flags != test.flags {
+			t.Errorf("%s('%s'): Expected flags '%v', received '%v'.", test.fn, test.input, test.flags, ctx.Flags)
+		}
+		if test.err != 0 {
+			if e, ok := err.(*NumError); !ok || e.Err != test.err || r != nil {
+				t.Errorf("%s('%s'): Expected error '%v', received '%v' (error '%v').", test.fn, test.input, test.err, r, err)
+			}
+			continue
+		}
+		expected, _ := new(big.Rat).SetString(test.output)
+		if err != nil || r.Cmp(expected) != 0 {
+			t.Errorf("%s('%s'): Expected '%s', received '%v' (error '%v').", test.fn, test.input, test.output, r, err)
+		}
+	}
+}
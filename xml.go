@@ -0,0 +1,71 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// xmlWhitespace is the set of whitespace characters defined by XML.
+const xmlWhitespace = " \t\r\n"
+
+// parseXML converts the xs:decimal lexical value s into a Decimal. Leading and
+// trailing whitespace is collapsed, and '.' is always the decimal separator.
+func parseXML(fnName, s string) (*Decimal, error) {
+	return parseDecimal(fnName, strings.Trim(s, xmlWhitespace), '.')
+}
+
+// MarshalXML implements the xml.Marshaler interface. The Decimal is encoded as
+// an xs:decimal value. A Decimal that is not Valid is omitted, and ErrNotFinite
+// is returned for NaN and infinities, which xs:decimal can not represent.
+func (d *Decimal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !d.Valid {
+		return nil
+	}
+	if d.form != finite {
+		return &NumError{"MarshalXML", d.String(), ErrNotFinite}
+	}
+	return e.EncodeElement(d.string('.'), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface. The element content
+// must be an xs:decimal value: an optional sign and decimal digits, with an
+// optional '.' separator. Exponents are not permitted.
+func (d *Decimal) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	decimal, err := parseXML("UnmarshalXML", s)
+	if err != nil {
+		return err
+	}
+	*d = *decimal
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. The Decimal is
+// encoded as an xs:decimal value. It otherwise behaves like MarshalXML.
+func (d *Decimal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !d.Valid {
+		return xml.Attr{}, nil
+	}
+	if d.form != finite {
+		return xml.Attr{}, &NumError{"MarshalXMLAttr", d.String(), ErrNotFinite}
+	}
+	return xml.Attr{Name: name, Value: d.string('.')}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface. The attribute
+// value must be an xs:decimal value, as described by UnmarshalXML.
+func (d *Decimal) UnmarshalXMLAttr(attr xml.Attr) error {
+	decimal, err := parseXML("UnmarshalXMLAttr", attr.Value)
+	if err != nil {
+		return err
+	}
+	*d = *decimal
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2014 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package decimal
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+type xmlAmount struct {
+	XMLName xml.Name `xml:"Amount"`
+	Rate    *Decimal `xml:"rate,attr,omitempty"`
+	Value   *Decimal `xml:"Value"`
+}
+
+func TestMarshalXML(t *testing.T) {
+	defer func(separator rune) { DecimalSeparator = separator }(DecimalSeparator)
+	DecimalSeparator = ','
+
+	rate, _ := parseDecimal("test", "1.25", '.')
+	value, _ := parseDecimal("test", "-1234.50", '.')
+	b, err := xml.Marshal(xmlAmount{Rate: rate, Value: value})
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	const expected = `<Amount rate="1.25"><Value>-1234.50</Value></Amount>`
+	if string(b) != expected {
+		t.Errorf("Expected '%s', received '%s'.", expected, string(b))
+	}
+
+	b, err = xml.Marshal(xmlAmount{Rate: &Decimal{}, Value: &Decimal{}})
+	if err != nil {
+		t.Fatalf("Expected success, received error '%v'.", err)
+	}
+	if string(b) != "<Amount></Amount>" {
+		t.Errorf("Expected invalid Decimals to be omitted, received '%s'.", string(b))
+	}
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	type xmlTest struct {
+		input      string
+		shouldFail bool
+		rate       string
+		value      string
+	}
+
+	tests := []xmlTest{
+		{input: `<Amount rate="1.25"><Value>100.00</Value></Amount>`, rate: "1.25", value: "100.00"},
+		{input: `<Amount rate=" +1.25 "><Value>` + "\n\t-0.5\r\n" + `</Value></Amount>`, rate: "1.25", value: "-0.5"},
+		{input: `<Amount><Value>.5</Value></Amount>`, value: "0.5"},
+		{input: `<Amount><Value>5.</Value></Amount>`, value: "5.0"},
+		{input: `<Amount><Value>1.5e2</Value></Amount>`, shouldFail: true},
+		{input: `<Amount><Value>1,5</Value></Amount>`, shouldFail: true},
+		{input: `<Amount><Value>1 000</Value></Amount>`, shouldFail: true},
+		{input: `<Amount><Value></Value></Amount>`, shouldFail: true},
+		{input: `<Amount><Value>INF</Value></Amount>`, shouldFail: true},
+		{input: `<Amount rate="1e3"><Value>1</Value></Amount>`, shouldFail: true},
+	}
+
+	for _, test := range tests {
+		var amount xmlAmount
+		err := xml.Unmarshal([]byte(test.input), &amount)
+		if err != nil {
+			if !test.shouldFail {
+				t.Errorf("'%s': Expected success, received error '%v'.", test.input, err)
+			} else if _, ok := err.(*NumError); !ok {
+				t.Errorf("'%s': Expected a *NumError, received '%v'.", test.input, err)
+			}
+			continue
+		}
+		if test.shouldFail {
+			t.Errorf("'%s': Expected failure.", test.input)
+			continue
+		}
+		if test.rate != "" && (amount.Rate == nil || amount.Rate.String() != test.rate) {
+			t.Errorf("'%s': Expected rate '%s', received '%v'.", test.input, test.rate, amount.Rate)
+		}
+		if amount.Value == nil || amount.Value.String() != test.value {
+			t.Errorf("'%s': Expected value '%s', received '%v'.", test.input, test.value, amount.Value)
+		}
+	}
+}